@@ -0,0 +1,54 @@
+package sparse
+
+import "math"
+
+// AngleDegrees is the angle between two Vectors, in degrees. It is NaN
+// if either is a zero vector.
+func AngleDegrees(v1 Vector, v2 Vector) float64 {
+	return Acos(v1, v2) * 180 / math.Pi
+}
+
+// CosineDistance is one minus the cosine similarity of two Vectors, in
+// [0, 2]. It is computed from the distance between the Vectors'
+// directions rather than by subtraction, so it keeps its precision for
+// nearly parallel Vectors. It is NaN if either is a zero vector.
+func CosineDistance(v1 Vector, v2 Vector) float64 {
+	diff, _ := directions(v1, v2)
+	return min(2, diff/2)
+}
+
+// AngularDistance is the angle between two Vectors as a fraction of π,
+// in [0, 1]. Unlike CosineDistance it is a metric, satisfying the
+// triangle inequality, so it suits indexes that prune by it. It is NaN
+// if either is a zero vector.
+func AngularDistance(v1 Vector, v2 Vector) float64 {
+	return Acos(v1, v2) / math.Pi
+}
+
+// angle is the angle between two Vectors, in radians, by the formula
+// 2·atan2(|u-w|, |u+w|) for their directions u and w. Unlike the arc
+// cosine of their similarity, it is accurate for nearly parallel and
+// nearly opposite Vectors.
+func angle(v1 Vector, v2 Vector) float64 {
+	diff, sum := directions(v1, v2)
+	return 2 * math.Atan2(math.Sqrt(diff), math.Sqrt(sum))
+}
+
+// directions returns the squared distance between the directions of two
+// Vectors, and the squared magnitude of their sum. Both are NaN if
+// either is a zero vector, so has no direction.
+func directions(v1 Vector, v2 Vector) (float64, float64) {
+	norm1, norm2 := v1.CachedMagnitude(), v2.CachedMagnitude()
+	if norm1 == 0 || norm2 == 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	var diff, sum float64
+	union(v1, v2, func(n int, d1 float64, d2 float64) {
+		u, w := d1/norm1, d2/norm2
+		diff += (u - w) * (u - w)
+		sum += (u + w) * (u + w)
+	})
+
+	return diff, sum
+}
@@ -0,0 +1,54 @@
+package sparse
+
+// Sub subtracts v2 from v1. The result takes the larger dimensionality
+// of the two and v1's options, as a Clone of it would, which apply to
+// its entries as they would through Set; see SubE for a checked
+// variant.
+func Sub(v1 Vector, v2 Vector) Vector {
+	ret := v1.Clone()
+	ret.dim = max(v1.dim, v2.dim)
+	ret.axpy(-1, v2)
+	return ret
+}
+
+// Mul multiplies two Vectors element-wise (the Hadamard product). Only
+// indices stored in both can be non-zero, so the result stores just
+// those. It takes the larger dimensionality of the two and v1's
+// options, like Sub; see MulE for a checked variant.
+func Mul(v1 Vector, v2 Vector) Vector {
+	ret := Vector{dim: max(v1.dim, v2.dim), opts: v1.opts.withoutObserver(), norms: v1.opts.newNormCache()}
+	ret.data = v1.opts.newMap(0)
+	intersect(v1, v2, func(n int, d1 float64, d2 float64) {
+		ret.put(n, d1*d2)
+	})
+
+	return ret
+}
+
+// Div divides v1 by v2 element-wise. Each entry stored in v1 is divided
+// by v2's value at its index following IEEE 754, so dividing by zero
+// yields ±Inf, or NaN for a zero stored in v1. Indices v1 does not store
+// stay zero, even where v2 is zero too, so the result's support never
+// exceeds v1's. It takes the larger dimensionality of the two and v1's
+// options, like Sub, so a NaN it yields panics under NaNReject; see
+// DivE for a checked variant.
+func Div(v1 Vector, v2 Vector) Vector {
+	ret := v1.Clone()
+	ret.dim = max(v1.dim, v2.dim)
+	for n, d := range v1.data {
+		ret.put(n, d/v2.data[n])
+	}
+
+	return ret
+}
+
+// put writes data on the n'th dimension of the result of arithmetic:
+// through Set if it has options, and directly into its map otherwise.
+func (v *Vector) put(n int, data float64) {
+	if v.opts != nil {
+		v.Set(n, data)
+		return
+	}
+
+	v.data[n] = data
+}
@@ -0,0 +1,42 @@
+package sparse
+
+import (
+	"math"
+	"testing"
+)
+
+func TestArithmeticKeepsOptions(t *testing.T) {
+	v1 := NewVectorFromArray([]float64{1, 2, 0, 4}, WithTolerance(0.01), WithNaNPolicy(NaNDrop))
+	v1.Set(2, 0) // dropped too: stored zeros count as within tolerance
+	v2 := NewVectorFromArray([]float64{0.995, 0, 3, 0.001})
+
+	for name, tt := range map[string]struct {
+		got  Vector
+		want []float64
+	}{
+		"Sub": {Sub(v1, v2), []float64{0, 2, -3, 3.999}},
+		"Mul": {Mul(v1, v2), []float64{0.995, 0, 0, 0}},
+		"Div": {Div(v1, v2), []float64{1 / 0.995, math.Inf(1), 0, 4000}},
+	} {
+		if tt.got.opts == nil || tt.got.opts.tolerance != 0.01 {
+			t.Errorf("%s lost v1's options", name)
+		}
+
+		if !ApproxEqual(tt.got, NewVectorFromArray(tt.want), 1e-9) {
+			t.Errorf("%s = %v, want %v", name, tt.got, tt.want)
+		}
+
+		for n, d := range tt.got.NonZeros() {
+			if math.Abs(d) < 0.01 || math.IsNaN(d) {
+				t.Errorf("%s stored %g at %d, which v1's options drop", name, d, n)
+			}
+		}
+	}
+
+	// A stored zero divided by zero is NaN, which NaNDrop leaves out.
+	v := NewVectorFromArray([]float64{0, 1}, WithNaNPolicy(NaNDrop))
+	v.Set(0, 0)
+	if got := Div(v, NewVector(2)); got.NNZ() != 1 || !math.IsInf(got.Get(1), 1) {
+		t.Errorf("Div() = %v, want only +Inf at 1", got)
+	}
+}
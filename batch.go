@@ -0,0 +1,142 @@
+package sparse
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// parallel calls fn(i) for each i in [0, n), spread over GOMAXPROCS
+// goroutines.
+func parallel(n int, fn func(i int)) {
+	parallelContext(context.Background(), n, fn)
+}
+
+// parallelContext is parallel, but stops handing out indices once ctx
+// is done, returning ctx's error if any were left uncalled.
+func parallelContext(ctx context.Context, n int, fn func(i int)) error {
+	workers := min(runtime.GOMAXPROCS(0), n)
+	next := make(chan int, workers)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+
+	var err error
+	for i := range n {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		next <- i
+	}
+
+	close(next)
+	wg.Wait()
+	return err
+}
+
+// magnitudes computes the CachedMagnitude of each of vs in parallel.
+func magnitudes(vs []Vector) []float64 {
+	ret := make([]float64, len(vs))
+	parallel(len(vs), func(i int) {
+		ret[i] = vs[i].CachedMagnitude()
+	})
+
+	return ret
+}
+
+// SimilarityMatrix computes the cosine similarity between every pair of
+// vs in parallel, computing each magnitude once. The result is
+// symmetric, with ones on the diagonal except for zero vectors, whose
+// similarities are NaN.
+func SimilarityMatrix(vs []Vector) [][]float64 {
+	ret, _ := similarityMatrix(context.Background(), vs)
+	return ret
+}
+
+// SimilarityMatrixContext computes SimilarityMatrix as the traced
+// operation "SimilarityMatrix" (see SetTracer). If ctx is done before
+// every row is computed, it returns ctx's error and no matrix.
+func SimilarityMatrixContext(ctx context.Context, vs []Vector) ([][]float64, error) {
+	var ret [][]float64
+	err := instrument(ctx, "SimilarityMatrix", func(ctx context.Context) error {
+		var err error
+		ret, err = similarityMatrix(ctx, vs)
+		return err
+	})
+
+	return ret, err
+}
+
+// similarityMatrix implements SimilarityMatrix and
+// SimilarityMatrixContext.
+func similarityMatrix(ctx context.Context, vs []Vector) ([][]float64, error) {
+	norms := magnitudes(vs)
+	ret := make([][]float64, len(vs))
+	for i := range ret {
+		ret[i] = make([]float64, len(vs))
+	}
+
+	err := parallelContext(ctx, len(vs), func(i int) {
+		ret[i][i] = 1
+		if norms[i] == 0 {
+			ret[i][i] = math.NaN()
+		}
+
+		for j := i + 1; j < len(vs); j++ {
+			d := cosine(vs[i], vs[j], norms[i], norms[j])
+			ret[i][j], ret[j][i] = d, d
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// SimilaritiesAgainst computes the cosine similarity between query and
+// each of vs in parallel. Similarities involving zero vectors are NaN.
+func SimilaritiesAgainst(query Vector, vs []Vector) []float64 {
+	ret, _ := similaritiesAgainst(context.Background(), query, vs)
+	return ret
+}
+
+// SimilaritiesAgainstContext computes SimilaritiesAgainst as the traced
+// operation "SimilaritiesAgainst" (see SetTracer). If ctx is done before
+// every similarity is computed, it returns ctx's error and no result.
+func SimilaritiesAgainstContext(ctx context.Context, query Vector, vs []Vector) ([]float64, error) {
+	var ret []float64
+	err := instrument(ctx, "SimilaritiesAgainst", func(ctx context.Context) error {
+		var err error
+		ret, err = similaritiesAgainst(ctx, query, vs)
+		return err
+	})
+
+	return ret, err
+}
+
+// similaritiesAgainst implements SimilaritiesAgainst and
+// SimilaritiesAgainstContext.
+func similaritiesAgainst(ctx context.Context, query Vector, vs []Vector) ([]float64, error) {
+	qnorm := query.CachedMagnitude()
+	ret := make([]float64, len(vs))
+	err := parallelContext(ctx, len(vs), func(i int) {
+		ret[i] = cosine(query, vs[i], qnorm, vs[i].CachedMagnitude())
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,65 @@
+package sparse
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSimilarityMatrix(t *testing.T) {
+	vs := []Vector{NewVectorFromArray([]float64{1, 0}), NewVectorFromArray([]float64{1, 1}), NewVector(2)}
+	m := SimilarityMatrix(vs)
+	if m[0][0] != 1 || math.Abs(m[0][1]-math.Sqrt(0.5)) > 1e-12 || m[0][1] != m[1][0] || !math.IsNaN(m[2][2]) {
+		t.Fatalf("SimilarityMatrix() = %v", m)
+	}
+
+	got, err := SimilarityMatrixContext(context.Background(), vs)
+	if err != nil || got[1][0] != m[1][0] {
+		t.Fatalf("SimilarityMatrixContext() = %v, %v", got, err)
+	}
+
+	sims := SimilaritiesAgainst(vs[0], vs)
+	if sims[0] != 1 || sims[1] != m[0][1] || !math.IsNaN(sims[2]) {
+		t.Fatalf("SimilaritiesAgainst() = %v", sims)
+	}
+}
+
+func TestContextVariantsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	vs := []Vector{NewVectorFromArray([]float64{1, 0}), NewVectorFromArray([]float64{0, 1})}
+	if m, err := SimilarityMatrixContext(ctx, vs); m != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("SimilarityMatrixContext() = %v, %v", m, err)
+	}
+
+	if s, err := SimilaritiesAgainstContext(ctx, vs[0], vs); s != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("SimilaritiesAgainstContext() = %v, %v", s, err)
+	}
+
+	if assign, _, err := KMeansContext(ctx, vs, 2, Euclidean, 1); assign != nil || !errors.Is(err, context.Canceled) {
+		t.Errorf("KMeansContext() = %v, %v", assign, err)
+	}
+}
+
+func TestKMeansContext(t *testing.T) {
+	vs := []Vector{
+		NewVectorFromArray([]float64{1, 0}), NewVectorFromArray([]float64{1.1, 0}),
+		NewVectorFromArray([]float64{0, 5}), NewVectorFromArray([]float64{0, 5.2}),
+	}
+
+	assign, centroids, err := KMeansContext(context.Background(), vs, 2, Euclidean, 1)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(centroids) != 2 || assign[0] != assign[1] || assign[2] != assign[3] || assign[0] == assign[2] {
+		t.Fatalf("KMeansContext() = %v, %v", assign, centroids)
+	}
+
+	want, _ := KMeans(vs, 2, Euclidean, 1)
+	for i := range want {
+		if want[i] != assign[i] {
+			t.Fatalf("KMeans() = %v, KMeansContext() = %v", want, assign)
+		}
+	}
+}
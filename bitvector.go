@@ -0,0 +1,159 @@
+package sparse
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// BitVector is a sparse binary vector, storing only which of its
+// dimensions are set as a sorted slice of indices. It suits binary
+// features, taking 8 bytes per set dimension and no per-entry map
+// overhead. BitVectors are immutable, and the zero value is an empty,
+// zero-dimensional BitVector.
+type BitVector struct {
+	dim     int
+	indices []int
+}
+
+// NewBitVector constructs a BitVector with dim number of dimensions and
+// the given dimensions set, which may repeat. It returns ErrOutOfRange
+// if an index falls outside the dimensions.
+func NewBitVector(dim int, indices []int) (BitVector, error) {
+	set := slices.Clone(indices)
+	slices.Sort(set)
+	set = slices.Compact(set)
+	if len(set) > 0 && (set[0] < 0 || set[len(set)-1] >= dim) {
+		return BitVector{}, fmt.Errorf("%w: indices [%d, %d] not in [0, %d)", ErrOutOfRange, set[0], set[len(set)-1], dim)
+	}
+
+	return BitVector{dim: dim, indices: set}, nil
+}
+
+// Binarize returns a BitVector with the Vector's non-zero dimensions
+// set. Entries outside the Vector's dimensions are left out.
+func (v Vector) Binarize() BitVector {
+	ret := BitVector{dim: v.dim, indices: make([]int, 0, len(v.data))}
+	for _, n := range v.Support() {
+		if n >= 0 && n < v.dim {
+			ret.indices = append(ret.indices, n)
+		}
+	}
+
+	return ret
+}
+
+// Vector returns the BitVector as a Vector with 1 on each set dimension.
+func (b BitVector) Vector() Vector {
+	ret := NewVector(b.dim, WithCapacity(len(b.indices)))
+	for _, n := range b.indices {
+		ret.data[n] = 1
+	}
+
+	return ret
+}
+
+// Size is the dimensionality of the vector.
+func (b BitVector) Size() int {
+	return b.dim
+}
+
+// Cardinality is the number of set dimensions.
+func (b BitVector) Cardinality() int {
+	return len(b.indices)
+}
+
+// Has reports whether the n'th dimension is set.
+func (b BitVector) Has(n int) bool {
+	_, ok := slices.BinarySearch(b.indices, n)
+	return ok
+}
+
+// Indices returns the set dimensions, in ascending order.
+func (b BitVector) Indices() []int {
+	return slices.Clone(b.indices)
+}
+
+// Union returns the dimensions set in either BitVector, with the larger
+// dimensionality of the two.
+func (b BitVector) Union(other BitVector) BitVector {
+	ret := BitVector{dim: max(b.dim, other.dim), indices: make([]int, 0, len(b.indices)+len(other.indices))}
+	i, j := 0, 0
+	for i < len(b.indices) && j < len(other.indices) {
+		switch x, y := b.indices[i], other.indices[j]; {
+		case x < y:
+			ret.indices = append(ret.indices, x)
+			i++
+		case x > y:
+			ret.indices = append(ret.indices, y)
+			j++
+		default:
+			ret.indices = append(ret.indices, x)
+			i++
+			j++
+		}
+	}
+
+	ret.indices = append(ret.indices, b.indices[i:]...)
+	ret.indices = append(ret.indices, other.indices[j:]...)
+	return ret
+}
+
+// Intersect returns the dimensions set in both BitVectors, with the
+// larger dimensionality of the two.
+func (b BitVector) Intersect(other BitVector) BitVector {
+	ret := BitVector{dim: max(b.dim, other.dim), indices: []int{}}
+	i, j := 0, 0
+	for i < len(b.indices) && j < len(other.indices) {
+		switch x, y := b.indices[i], other.indices[j]; {
+		case x < y:
+			i++
+		case x > y:
+			j++
+		default:
+			ret.indices = append(ret.indices, x)
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
+
+// Difference returns the dimensions set in the BitVector but not in
+// other, keeping the BitVector's dimensionality.
+func (b BitVector) Difference(other BitVector) BitVector {
+	ret := BitVector{dim: b.dim, indices: make([]int, 0, len(b.indices))}
+	j := 0
+	for _, x := range b.indices {
+		for j < len(other.indices) && other.indices[j] < x {
+			j++
+		}
+
+		if j == len(other.indices) || other.indices[j] != x {
+			ret.indices = append(ret.indices, x)
+		}
+	}
+
+	return ret
+}
+
+// String formats the BitVector as its dim and set dimensions, e.g.
+// "dim=10 {1, 7}".
+func (b BitVector) String() string {
+	var sb strings.Builder
+	sb.WriteString("dim=")
+	sb.WriteString(strconv.Itoa(b.dim))
+	sb.WriteString(" {")
+	for i, n := range b.indices {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(strconv.Itoa(n))
+	}
+
+	sb.WriteByte('}')
+	return sb.String()
+}
@@ -0,0 +1,81 @@
+package sparse
+
+import "math"
+
+// Level1 is the set of BLAS level-1 kernels over sparse Vectors, shaped
+// after gonum's blas64 package so numerical code written against it can
+// swap in sparse vectors with minimal changes. As in blas64, Axpy and
+// Scal update their vector argument in place, which they take by
+// pointer like every other mutating operation in the package.
+type Level1 interface {
+	// Dot computes x·y.
+	Dot(x, y Vector) float64
+
+	// Nrm2 computes the Euclidean norm of x.
+	Nrm2(x Vector) float64
+
+	// Asum computes the sum of the absolute values of x.
+	Asum(x Vector) float64
+
+	// Axpy adds alpha*x to y in place.
+	Axpy(alpha float64, x Vector, y *Vector)
+
+	// Scal scales x by alpha in place.
+	Scal(alpha float64, x *Vector)
+
+	// Iamax returns the index of the first element of x with the largest
+	// absolute value, or -1 if x has no dimensions.
+	Iamax(x Vector) int
+}
+
+// level1 is the default Level1 implementation.
+type level1 struct{}
+
+// Implementation returns the package's Level1 kernels.
+func Implementation() Level1 {
+	return level1{}
+}
+
+func (level1) Dot(x, y Vector) float64 {
+	return Dot(x, y)
+}
+
+func (level1) Nrm2(x Vector) float64 {
+	return x.Magnitude()
+}
+
+func (level1) Asum(x Vector) float64 {
+	ret := float64(0)
+	for _, d := range x.data {
+		ret += math.Abs(d)
+	}
+
+	return ret
+}
+
+func (level1) Axpy(alpha float64, x Vector, y *Vector) {
+	if alpha == 0 || len(x.data) == 0 {
+		return
+	}
+
+	y.axpy(alpha, x)
+}
+
+func (level1) Scal(alpha float64, x *Vector) {
+	x.ScaleInPlace(alpha)
+}
+
+func (level1) Iamax(x Vector) int {
+	if x.dim == 0 {
+		return -1
+	}
+
+	idx, max := 0, float64(0)
+	for n, d := range x.data {
+		if a := math.Abs(d); a > max || (a == max && n < idx) {
+			idx, max = n, a
+		}
+	}
+
+	return idx
+}
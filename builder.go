@@ -0,0 +1,121 @@
+package sparse
+
+import "fmt"
+
+// VectorBuilder constructs a Vector through chained calls, validating
+// everything once in Build:
+//
+//	v, err := sparse.NewVectorBuilder().Dim(10).Set(1, 0.5).Set(7, -2).Build()
+type VectorBuilder struct {
+	dim     int
+	dimSet  bool
+	entries map[int]float64
+	opts    []Option
+	err     error
+}
+
+// NewVectorBuilder starts building a Vector constructed with opts.
+func NewVectorBuilder(opts ...Option) *VectorBuilder {
+	return &VectorBuilder{entries: map[int]float64{}, opts: opts}
+}
+
+// Dim sets the Vector's dimensionality. Without it, Build uses one more
+// than the largest index set.
+func (b *VectorBuilder) Dim(n int) *VectorBuilder {
+	b.dim, b.dimSet = n, true
+	return b
+}
+
+// Set data on the n'th dimension, replacing any earlier value.
+func (b *VectorBuilder) Set(n int, data float64) *VectorBuilder {
+	b.entries[n] = data
+	return b
+}
+
+// Add value to the n'th dimension, accumulating with any earlier value,
+// as when summing repeated indices from a COO source.
+func (b *VectorBuilder) Add(n int, value float64) *VectorBuilder {
+	b.entries[n] += value
+	return b
+}
+
+// Capacity sizes the builder's storage for n entries up front, when the
+// number of entries is known in advance. It should precede the first
+// Set or Add.
+func (b *VectorBuilder) Capacity(n int) *VectorBuilder {
+	if len(b.entries) == 0 {
+		b.entries = make(map[int]float64, n)
+	}
+
+	return b
+}
+
+// FromMap sets every entry of m.
+func (b *VectorBuilder) FromMap(m map[int]float64) *VectorBuilder {
+	for n, d := range m {
+		b.Set(n, d)
+	}
+
+	return b
+}
+
+// FromSlice sets the non-zero values of a dense slice, and grows an
+// inferred dimensionality to at least its length.
+func (b *VectorBuilder) FromSlice(arr []float64) *VectorBuilder {
+	for n, d := range arr {
+		if d != 0 {
+			b.Set(n, d)
+		}
+	}
+
+	if !b.dimSet {
+		b.dim = max(b.dim, len(arr))
+	}
+
+	return b
+}
+
+// FromPairs sets indices[i] to values[i] for each i.
+func (b *VectorBuilder) FromPairs(indices []int, values []float64) *VectorBuilder {
+	if len(indices) != len(values) {
+		b.err = fmt.Errorf("%w: %d indices but %d values", ErrDimensionMismatch, len(indices), len(values))
+		return b
+	}
+
+	for i, n := range indices {
+		b.Set(n, values[i])
+	}
+
+	return b
+}
+
+// Build validates the entries and constructs the Vector, with storage
+// sized to exactly its entries. Zero entries, such as those that
+// accumulated to zero, are left out. It returns the first error from a
+// chained call, or ErrOutOfRange if an index falls outside the Vector's
+// dimensions.
+func (b *VectorBuilder) Build() (Vector, error) {
+	if b.err != nil {
+		return Vector{}, b.err
+	}
+
+	dim := b.dim
+	if !b.dimSet {
+		for n := range b.entries {
+			dim = max(dim, n+1)
+		}
+	}
+
+	ret := NewVector(dim, append([]Option{WithCapacity(len(b.entries))}, b.opts...)...)
+	for n, d := range b.entries {
+		if d == 0 {
+			if err := ret.admit(n); err != nil {
+				return Vector{}, err
+			}
+		} else if err := ret.SetChecked(n, d); err != nil {
+			return Vector{}, err
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,152 @@
+package sparse
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDimensionMismatch is returned when operands have incompatible
+// dimensions.
+var ErrDimensionMismatch = errors.New("sparse: dimension mismatch")
+
+// ErrZeroVector is returned when an operation is undefined for a zero
+// vector, such as the angle between it and another.
+var ErrZeroVector = errors.New("sparse: zero vector")
+
+// checkSameDim returns ErrDimensionMismatch unless v1 and v2 have the
+// same dimensionality.
+func checkSameDim(v1 Vector, v2 Vector) error {
+	if v1.dim != v2.dim {
+		return fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v1.dim, v2.dim)
+	}
+
+	return nil
+}
+
+// checkInRange returns ErrOutOfRange if v stores an entry outside its
+// dimensions, as a Lenient Vector may.
+func checkInRange(v Vector) error {
+	for n := range v.data {
+		if n < 0 || n >= v.dim {
+			return fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+		}
+	}
+
+	return nil
+}
+
+// AddE adds two Vectors, returning ErrDimensionMismatch unless their
+// dimensions match.
+func AddE(v1 Vector, v2 Vector) (Vector, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return Vector{}, err
+	}
+
+	return Add(v1, v2), nil
+}
+
+// DotE computes the dot product of two Vectors over the intersection of
+// their supports, returning ErrDimensionMismatch unless their
+// dimensions match and ErrOutOfRange if either stores an entry outside
+// them.
+func DotE(v1 Vector, v2 Vector) (float64, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return 0, err
+	} else if err := errors.Join(checkInRange(v1), checkInRange(v2)); err != nil {
+		return 0, err
+	}
+
+	return Dot(v1, v2), nil
+}
+
+// AppendE appends Vectors to v1 like Append, returning ErrOutOfRange if
+// any stores an entry outside its dimensions, which would otherwise
+// collide with or misplace appended entries, or if the result's
+// dimensionality would overflow.
+func AppendE(v1 Vector, vs ...Vector) (Vector, error) {
+	return appendVectors(v1, vs, true)
+}
+
+// ConcatE joins Vectors like Concat, returning ErrOutOfRange under the
+// same conditions as AppendE.
+func ConcatE(vs ...Vector) (Vector, error) {
+	return appendVectors(NewVector(0), vs, true)
+}
+
+// SubE subtracts v2 from v1, returning ErrDimensionMismatch unless their
+// dimensions match.
+func SubE(v1 Vector, v2 Vector) (Vector, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return Vector{}, err
+	}
+
+	return Sub(v1, v2), nil
+}
+
+// MulE multiplies two Vectors element-wise, returning
+// ErrDimensionMismatch unless their dimensions match.
+func MulE(v1 Vector, v2 Vector) (Vector, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return Vector{}, err
+	}
+
+	return Mul(v1, v2), nil
+}
+
+// DivE divides v1 by v2 element-wise, returning ErrDimensionMismatch
+// unless their dimensions match.
+func DivE(v1 Vector, v2 Vector) (Vector, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return Vector{}, err
+	}
+
+	return Div(v1, v2), nil
+}
+
+// SimilarityE computes the cosine similarity of two Vectors, returning
+// ErrDimensionMismatch unless their dimensions match and ErrZeroVector
+// if either is a zero vector.
+func SimilarityE(v1 Vector, v2 Vector) (float64, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return 0, err
+	}
+
+	norm1, norm2 := v1.CachedMagnitude(), v2.CachedMagnitude()
+	if norm1 == 0 || norm2 == 0 {
+		return 0, ErrZeroVector
+	}
+
+	return cosine(v1, v2, norm1, norm2), nil
+}
+
+// AcosE computes the angle between two Vectors, returning an error
+// under the same conditions as SimilarityE.
+func AcosE(v1 Vector, v2 Vector) (float64, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return 0, err
+	} else if v1.CachedMagnitude() == 0 || v2.CachedMagnitude() == 0 {
+		return 0, ErrZeroVector
+	}
+
+	return angle(v1, v2), nil
+}
+
+// CovarianceE computes the covariance of two Vectors, returning
+// ErrDimensionMismatch unless their dimensions match.
+func CovarianceE(v1 Vector, v2 Vector) (float64, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return 0, err
+	}
+
+	return Covariance(v1, v2), nil
+}
+
+// CorrelationE computes the correlation of two Vectors, returning
+// ErrDimensionMismatch unless their dimensions match.
+func CorrelationE(v1 Vector, v2 Vector) (float64, error) {
+	if err := checkSameDim(v1, v2); err != nil {
+		return 0, err
+	}
+
+	return Correlation(v1, v2), nil
+}
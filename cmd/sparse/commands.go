@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math"
+
+	"github.com/angadn/sparse"
+)
+
+func convert(args []string) error {
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	from := fs.String("from", "", "input format")
+	to := fs.String("to", "", "output format")
+	if err := parse(fs, args, 2); err != nil {
+		return err
+	}
+
+	ds, err := load(fs.Arg(0), *from)
+	if err != nil {
+		return err
+	}
+
+	return save(fs.Arg(1), *to, ds)
+}
+
+func stats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	format := fs.String("format", "", "input format")
+	if err := parse(fs, args, 1); err != nil {
+		return err
+	}
+
+	for _, path := range fs.Args() {
+		ds, err := load(path, *format)
+		if err != nil {
+			return err
+		}
+
+		var (
+			dim, nnz          int
+			minNorm, maxNorm  = math.Inf(1), math.Inf(-1)
+			sumNorm, sumNorm1 float64
+		)
+
+		for _, row := range ds.rows {
+			dim = max(dim, row.Size())
+			idx := nonZeros(row)
+			nnz += len(idx)
+
+			norm := row.Magnitude()
+			minNorm, maxNorm = min(minNorm, norm), max(maxNorm, norm)
+			sumNorm += norm
+			for _, n := range idx {
+				sumNorm1 += math.Abs(row.Get(n))
+			}
+		}
+
+		density := float64(0)
+		if cells := len(ds.rows) * dim; cells > 0 {
+			density = float64(nnz) / float64(cells)
+		}
+
+		fmt.Printf("%s:\n", path)
+		fmt.Printf("  vectors  %d\n", len(ds.rows))
+		fmt.Printf("  dim      %d\n", dim)
+		fmt.Printf("  nnz      %d\n", nnz)
+		fmt.Printf("  density  %g\n", density)
+		if len(ds.rows) > 0 {
+			fmt.Printf("  l2 norm  min %g, mean %g, max %g\n", minNorm, sumNorm/float64(len(ds.rows)), maxNorm)
+			fmt.Printf("  l1 norm  mean %g\n", sumNorm1/float64(len(ds.rows)))
+		}
+	}
+
+	return nil
+}
+
+func topk(args []string) error {
+	fs := flag.NewFlagSet("topk", flag.ContinueOnError)
+	k := fs.Int("k", 10, "number of results")
+	format := fs.String("format", "", "input format")
+	if err := parse(fs, args, 2); err != nil {
+		return err
+	}
+
+	query, err := load(fs.Arg(0), *format)
+	if err != nil {
+		return err
+	} else if len(query.rows) == 0 {
+		return fmt.Errorf("%s: no query vector", fs.Arg(0))
+	}
+
+	ds, err := load(fs.Arg(1), *format)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	store := sparse.NewMemoryStore()
+	for i, row := range ds.rows {
+		store.Upsert(ctx, fmt.Sprint(i), row)
+	}
+
+	matches, err := sparse.Query(ctx, store, query.rows[0], *k)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		fmt.Printf("%s\t%g\n", m.ID, m.Score)
+	}
+
+	return nil
+}
+
+func similarity(args []string) error {
+	fs := flag.NewFlagSet("similarity", flag.ContinueOnError)
+	format := fs.String("format", "", "input format")
+	if err := parse(fs, args, 2); err != nil {
+		return err
+	}
+
+	a, err := load(fs.Arg(0), *format)
+	if err != nil {
+		return err
+	}
+
+	b, err := load(fs.Arg(1), *format)
+	if err != nil {
+		return err
+	} else if len(a.rows) != len(b.rows) {
+		return fmt.Errorf("%d vectors in %s but %d in %s", len(a.rows), fs.Arg(0), len(b.rows), fs.Arg(1))
+	}
+
+	for i := range a.rows {
+		fmt.Printf("%d\t%g\n", i, sparse.Similarity(a.rows[i], b.rows[i]))
+	}
+
+	return nil
+}
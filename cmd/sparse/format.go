@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/angadn/sparse"
+)
+
+// dataset is a list of vectors with optional per-row labels, as read
+// from or written to one of the supported file formats.
+type dataset struct {
+	labels []float64
+	rows   []sparse.Vector
+}
+
+// formats are the supported file formats, keyed by name.
+var formats = map[string]struct {
+	read  func(io.Reader) (dataset, error)
+	write func(io.Writer, dataset) error
+}{
+	"mtx":    {readMTX, writeMTX},
+	"libsvm": {readLibSVM, writeLibSVM},
+	"csv":    {readCSV, writeCSV},
+	"json":   {readJSON, writeJSON},
+	"binary": {readBinary, writeBinary},
+}
+
+// formatOf guesses a file's format from its extension.
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mtx":
+		return "mtx"
+	case ".svm", ".libsvm", ".txt":
+		return "libsvm"
+	case ".csv":
+		return "csv"
+	case ".json", ".jsonl":
+		return "json"
+	default:
+		return "binary"
+	}
+}
+
+// readMTX reads a MatrixMarket coordinate file, one Vector per row.
+func readMTX(r io.Reader) (dataset, error) {
+	rows, err := sparse.ReadMatrixMarket(r)
+	return dataset{rows: rows}, err
+}
+
+// writeMTX writes a MatrixMarket coordinate file, one row per Vector.
+func writeMTX(w io.Writer, ds dataset) error {
+	return sparse.WriteMatrixMarket(w, ds.rows)
+}
+
+// readLibSVM reads the libsvm "label index:value ..." format.
+func readLibSVM(r io.Reader) (dataset, error) {
+	labels, rows, err := sparse.ReadLibSVM(r)
+	return dataset{labels: labels, rows: rows}, err
+}
+
+// writeLibSVM writes the libsvm format.
+func writeLibSVM(w io.Writer, ds dataset) error {
+	return sparse.WriteLibSVM(w, ds.labels, ds.rows)
+}
+
+// readCSV reads a wide CSV file, one Vector per record.
+func readCSV(r io.Reader) (dataset, error) {
+	rows, err := sparse.FromCSV(r, sparse.CSVOptions{})
+	return dataset{rows: rows}, err
+}
+
+// writeCSV writes a wide CSV file, one record per Vector.
+func writeCSV(w io.Writer, ds dataset) error {
+	return sparse.WriteCSV(w, ds.rows, sparse.CSVOptions{})
+}
+
+// readJSON reads a stream of JSON vectors, typically one per line.
+func readJSON(r io.Reader) (dataset, error) {
+	var ds dataset
+	dec := json.NewDecoder(r)
+	for {
+		var row sparse.Vector
+		if err := dec.Decode(&row); err == io.EOF {
+			return ds, nil
+		} else if err != nil {
+			return ds, fmt.Errorf("json: %w", err)
+		}
+
+		ds.rows = append(ds.rows, row)
+	}
+}
+
+// writeJSON writes one JSON vector per line.
+func writeJSON(w io.Writer, ds dataset) error {
+	enc := json.NewEncoder(w)
+	for _, row := range ds.rows {
+		if err := enc.Encode(row.Compact()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readBinary reads a stream of Vectors written by writeBinary.
+func readBinary(r io.Reader) (dataset, error) {
+	var ds dataset
+	for row, err := range sparse.NewDecoder(r).All() {
+		if err != nil {
+			return ds, fmt.Errorf("binary: %w", err)
+		}
+
+		ds.rows = append(ds.rows, row)
+	}
+
+	return ds, nil
+}
+
+// writeBinary writes each Vector with a sparse.Encoder.
+func writeBinary(w io.Writer, ds dataset) error {
+	bw := bufio.NewWriter(w)
+	enc := sparse.NewEncoder(bw)
+	for _, row := range ds.rows {
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// nonZeros lists the indices of v's non-zero dimensions in ascending
+// order.
+func nonZeros(v sparse.Vector) []int {
+	var ret []int
+	for n := range v.SortedNonZeros() {
+		if n >= 0 && n < v.Size() {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,122 @@
+// Command sparse converts, inspects and compares files of sparse
+// vectors.
+//
+// Usage:
+//
+//	sparse convert [-from fmt] [-to fmt] IN OUT
+//	sparse stats [-format fmt] FILE...
+//	sparse topk [-k n] [-format fmt] QUERY FILE
+//	sparse similarity [-format fmt] A B
+//
+// Supported formats are mtx (MatrixMarket coordinate), libsvm, csv
+// (one dense vector per line), json (one vector per line) and binary.
+// A path of "-" is stdin or stdout, and formats are guessed from file
+// extensions unless given.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+var commands = map[string]func(args []string) error{
+	"convert":    convert,
+	"stats":      stats,
+	"topk":       topk,
+	"similarity": similarity,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+
+	if err := cmd(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "sparse %s: %v\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: sparse convert|stats|topk|similarity [flags] files...")
+	os.Exit(2)
+}
+
+// load reads a dataset from path, guessing its format unless format is
+// non-empty.
+func load(path, format string) (dataset, error) {
+	if format == "" {
+		format = formatOf(path)
+	}
+
+	f, ok := formats[format]
+	if !ok {
+		return dataset{}, fmt.Errorf("unknown format %q", format)
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return dataset{}, err
+		}
+
+		defer file.Close()
+		r = file
+	}
+
+	ds, err := f.read(r)
+	if err != nil {
+		return ds, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return ds, nil
+}
+
+// save writes a dataset to path, guessing its format unless format is
+// non-empty.
+func save(path, format string, ds dataset) error {
+	if format == "" {
+		format = formatOf(path)
+	}
+
+	f, ok := formats[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+
+	if path == "-" {
+		return f.write(os.Stdout, ds)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := f.write(file, ds); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// parse parses a subcommand's flags, requiring at least n positional
+// arguments.
+func parse(fs *flag.FlagSet, args []string, n int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	} else if fs.NArg() < n {
+		return fmt.Errorf("expected %d arguments, got %d", n, fs.NArg())
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+module github.com/angadn/sparse/cmd/sparsed
+
+go 1.25.0
+
+require (
+	github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
+	github.com/angadn/sparse/sparsepb v0.0.0-20261014063915-a41df7f221c5
+	google.golang.org/grpc v1.84.0
+)
+
+require (
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260706201446-f0a921348800 // indirect
+	google.golang.org/protobuf v1.36.11 // indirect
+)
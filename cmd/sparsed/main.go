@@ -0,0 +1,29 @@
+// Command sparsed serves an in-memory sparse.Store over gRPC.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/angadn/sparse"
+	"github.com/angadn/sparse/sparsepb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := grpc.NewServer()
+	sparsepb.RegisterVectorServiceServer(srv, newServer(sparse.NewMemoryStore()))
+	log.Printf("sparsed listening on %s", lis.Addr())
+	if err := srv.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
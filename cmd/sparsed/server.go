@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/angadn/sparse"
+	"github.com/angadn/sparse/sparsepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// server implements sparsepb.VectorServiceServer over a sparse.Store.
+type server struct {
+	sparsepb.UnimplementedVectorServiceServer
+	store sparse.Store
+}
+
+func newServer(store sparse.Store) *server {
+	return &server{store: store}
+}
+
+// toStatus converts an error from the store or the sparse package to a
+// gRPC status error, so that clients can tell invalid arguments from
+// failures of the server.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, sparse.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, sparse.ErrOutOfRange) || errors.Is(err, sparse.ErrDimensionMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func (s *server) Upsert(ctx context.Context, req *sparsepb.UpsertRequest) (*sparsepb.UpsertResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	v, err := sparsepb.FromProto(req.GetVector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.store.Upsert(ctx, req.GetId(), v); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &sparsepb.UpsertResponse{}, nil
+}
+
+func (s *server) Delete(ctx context.Context, req *sparsepb.DeleteRequest) (*sparsepb.DeleteResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	if err := s.store.Delete(ctx, req.GetId()); err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &sparsepb.DeleteResponse{}, nil
+}
+
+func (s *server) Query(ctx context.Context, req *sparsepb.QueryRequest) (*sparsepb.QueryResponse, error) {
+	q, err := sparsepb.FromProto(req.GetVector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	matches, err := sparse.Query(ctx, s.store, q, int(req.GetK()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	res := &sparsepb.QueryResponse{}
+	for _, m := range matches {
+		res.Matches = append(res.Matches, &sparsepb.Match{Id: m.ID, Score: m.Score})
+	}
+
+	return res, nil
+}
+
+func (s *server) Similarity(ctx context.Context, req *sparsepb.SimilarityRequest) (*sparsepb.SimilarityResponse, error) {
+	a, err := sparsepb.FromProto(req.GetA())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	b, err := sparsepb.FromProto(req.GetB())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &sparsepb.SimilarityResponse{Similarity: sparse.Similarity(a, b)}, nil
+}
+
+func (s *server) Arithmetic(ctx context.Context, req *sparsepb.ArithmeticRequest) (*sparsepb.ArithmeticResponse, error) {
+	a, err := sparsepb.FromProto(req.GetA())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var ret sparse.Vector
+	switch req.GetOp() {
+	case sparsepb.ArithmeticRequest_OP_TIMES:
+		ret = a.Times(req.GetScalar())
+	case sparsepb.ArithmeticRequest_OP_ADD, sparsepb.ArithmeticRequest_OP_APPEND:
+		b, err := sparsepb.FromProto(req.GetB())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if req.GetOp() == sparsepb.ArithmeticRequest_OP_ADD {
+			ret = sparse.Add(a, b)
+		} else {
+			ret = sparse.Append(a, b)
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported op %v", req.GetOp())
+	}
+
+	return &sparsepb.ArithmeticResponse{Result: sparsepb.ToProto(ret)}, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/angadn/sparse"
+	"github.com/angadn/sparse/sparsepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial serves store over an in-memory connection, returning a client
+// for it.
+func dial(t *testing.T, store sparse.Store) sparsepb.VectorServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	sparsepb.RegisterVectorServiceServer(srv, newServer(store))
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { conn.Close() })
+	return sparsepb.NewVectorServiceClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	client := dial(t, sparse.NewMemoryStore())
+	for id, v := range map[string][]float64{"a": {1, 0, 0}, "b": {0, 1, 0}} {
+		req := &sparsepb.UpsertRequest{Id: id, Vector: sparsepb.ToProto(sparse.NewVectorFromArray(v))}
+		if _, err := client.Upsert(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q := sparsepb.ToProto(sparse.NewVectorFromArray([]float64{1, 0.5, 0}))
+	res, err := client.Query(ctx, &sparsepb.QueryRequest{Vector: q, K: 2})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(res.GetMatches()) != 2 || res.GetMatches()[0].GetId() != "a" {
+		t.Fatalf("Query() = %v, want a then b", res.GetMatches())
+	}
+
+	if _, err := client.Delete(ctx, &sparsepb.DeleteRequest{Id: "a"}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err = client.Query(ctx, &sparsepb.QueryRequest{Vector: q, K: 2})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(res.GetMatches()) != 1 || res.GetMatches()[0].GetId() != "b" {
+		t.Errorf("Query() after Delete = %v, want b alone", res.GetMatches())
+	}
+}
+
+// failingStore is a Store whose every operation fails with err.
+type failingStore struct {
+	sparse.Store
+	err error
+}
+
+func (s failingStore) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	return s.err
+}
+
+func (s failingStore) Delete(ctx context.Context, id string) error {
+	return s.err
+}
+
+func TestServerErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	v := sparsepb.ToProto(sparse.NewVectorFromArray([]float64{1}))
+	if _, err := dial(t, sparse.NewMemoryStore()).Query(ctx, &sparsepb.QueryRequest{Vector: v, K: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Query with k = -1: got %v, want InvalidArgument", err)
+	}
+
+	for _, tt := range []struct {
+		err  error
+		want codes.Code
+	}{
+		{fmt.Errorf("%w: dim 3", sparse.ErrDimensionMismatch), codes.InvalidArgument},
+		{fmt.Errorf("%w: index 9", sparse.ErrOutOfRange), codes.InvalidArgument},
+		{sparse.ErrNotFound, codes.NotFound},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{errors.New("disk full"), codes.Internal},
+	} {
+		client := dial(t, failingStore{err: tt.err})
+		if _, err := client.Upsert(ctx, &sparsepb.UpsertRequest{Id: "a", Vector: v}); status.Code(err) != tt.want {
+			t.Errorf("Upsert failing with %v: got %v, want %v", tt.err, err, tt.want)
+		} else if _, err := client.Delete(ctx, &sparsepb.DeleteRequest{Id: "a"}); status.Code(err) != tt.want {
+			t.Errorf("Delete failing with %v: got %v, want %v", tt.err, err, tt.want)
+		}
+	}
+}
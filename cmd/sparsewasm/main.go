@@ -0,0 +1,198 @@
+//go:build js && wasm
+
+// Command sparsewasm exposes sparse vector similarity to JavaScript
+// when built with GOOS=js GOARCH=wasm.
+//
+// It installs a global sparse object whose functions take and return
+// vectors as plain {dim: N, data: {index: value, ...}} objects:
+//
+//	sparse.fromArray(values) -> vector
+//	sparse.similarity(a, b) -> number
+//	sparse.topK(query, candidates, k) -> [{index, score}, ...]
+//
+// A function given the wrong number or kinds of arguments returns an
+// Error object describing the problem instead of its result.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"syscall/js"
+
+	"github.com/angadn/sparse"
+)
+
+func main() {
+	api := js.Global().Get("Object").New()
+	api.Set("fromArray", js.FuncOf(fromArray))
+	api.Set("similarity", js.FuncOf(similarity))
+	api.Set("topK", js.FuncOf(topK))
+	js.Global().Set("sparse", api)
+
+	// Keep the Go runtime alive to serve calls.
+	select {}
+}
+
+// jsError converts err to a JS Error object.
+func jsError(err error) js.Value {
+	return js.Global().Get("Error").New(err.Error())
+}
+
+// checkArgs returns an error unless there are want args.
+func checkArgs(name string, args []js.Value, want int) error {
+	if len(args) != want {
+		return fmt.Errorf("sparse.%s: want %d arguments, got %d", name, want, len(args))
+	}
+
+	return nil
+}
+
+// isArray reports whether o is a JS array.
+func isArray(o js.Value) bool {
+	return js.Global().Get("Array").Call("isArray", o).Bool()
+}
+
+// toInt converts a JS number that holds a non-negative integer.
+func toInt(o js.Value, what string) (int, error) {
+	if o.Type() != js.TypeNumber {
+		return 0, fmt.Errorf("%s must be a number, got %s", what, o.Type())
+	} else if f := o.Float(); f < 0 || f != math.Trunc(f) || f > math.MaxInt32 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %v", what, f)
+	}
+
+	return o.Int(), nil
+}
+
+// toVector converts a JS vector object.
+func toVector(o js.Value) (sparse.Vector, error) {
+	if o.Type() != js.TypeObject {
+		return sparse.Vector{}, fmt.Errorf("vector must be an object, got %s", o.Type())
+	}
+
+	dim, err := toInt(o.Get("dim"), "vector dim")
+	if err != nil {
+		return sparse.Vector{}, err
+	}
+
+	ret := sparse.NewVector(dim)
+	data := o.Get("data")
+	if data.Type() != js.TypeObject {
+		return sparse.Vector{}, fmt.Errorf("vector data must be an object, got %s", data.Type())
+	}
+
+	keys := js.Global().Get("Object").Call("keys", data)
+	for i := 0; i < keys.Length(); i++ {
+		k := keys.Index(i).String()
+		n, err := strconv.Atoi(k)
+		d := data.Get(k)
+		if err != nil || n < 0 || n >= dim {
+			return sparse.Vector{}, fmt.Errorf("vector index %q not in [0, %d)", k, dim)
+		} else if d.Type() != js.TypeNumber {
+			return sparse.Vector{}, fmt.Errorf("vector value at %s must be a number, got %s", k, d.Type())
+		}
+
+		ret.Set(n, d.Float())
+	}
+
+	return ret, nil
+}
+
+// fromVector converts v to a JS vector object.
+func fromVector(v sparse.Vector) js.Value {
+	data := js.Global().Get("Object").New()
+	for n, d := range v.NonZeros() {
+		if n >= 0 && n < v.Size() {
+			data.Set(strconv.Itoa(n), d)
+		}
+	}
+
+	ret := js.Global().Get("Object").New()
+	ret.Set("dim", v.Size())
+	ret.Set("data", data)
+	return ret
+}
+
+func fromArray(this js.Value, args []js.Value) any {
+	if err := checkArgs("fromArray", args, 1); err != nil {
+		return jsError(err)
+	} else if !isArray(args[0]) {
+		return jsError(fmt.Errorf("sparse.fromArray: values must be an array, got %s", args[0].Type()))
+	}
+
+	arr := make([]float64, args[0].Length())
+	for i := range arr {
+		d := args[0].Index(i)
+		if d.Type() != js.TypeNumber {
+			return jsError(fmt.Errorf("sparse.fromArray: value %d must be a number, got %s", i, d.Type()))
+		}
+
+		arr[i] = d.Float()
+	}
+
+	return fromVector(sparse.NewVectorFromArray(arr))
+}
+
+func similarity(this js.Value, args []js.Value) any {
+	if err := checkArgs("similarity", args, 2); err != nil {
+		return jsError(err)
+	}
+
+	a, err := toVector(args[0])
+	if err != nil {
+		return jsError(fmt.Errorf("sparse.similarity: a: %w", err))
+	}
+
+	b, err := toVector(args[1])
+	if err != nil {
+		return jsError(fmt.Errorf("sparse.similarity: b: %w", err))
+	}
+
+	return sparse.Similarity(a, b)
+}
+
+func topK(this js.Value, args []js.Value) any {
+	if err := checkArgs("topK", args, 3); err != nil {
+		return jsError(err)
+	}
+
+	query, err := toVector(args[0])
+	if err != nil {
+		return jsError(fmt.Errorf("sparse.topK: query: %w", err))
+	} else if !isArray(args[1]) {
+		return jsError(fmt.Errorf("sparse.topK: candidates must be an array, got %s", args[1].Type()))
+	}
+
+	k, err := toInt(args[2], "k")
+	if err != nil {
+		return jsError(fmt.Errorf("sparse.topK: %w", err))
+	}
+
+	ctx := context.Background()
+	store := sparse.NewMemoryStore()
+	for i := 0; i < args[1].Length(); i++ {
+		v, err := toVector(args[1].Index(i))
+		if err != nil {
+			return jsError(fmt.Errorf("sparse.topK: candidate %d: %w", i, err))
+		}
+
+		store.Upsert(ctx, strconv.Itoa(i), v)
+	}
+
+	matches, err := sparse.Query(ctx, store, query, k)
+	if err != nil {
+		return jsError(fmt.Errorf("sparse.topK: %w", err))
+	}
+
+	ret := js.Global().Get("Array").New()
+	for _, m := range matches {
+		n, _ := strconv.Atoi(m.ID)
+		o := js.Global().Get("Object").New()
+		o.Set("index", n)
+		o.Set("score", m.Score)
+		ret.Call("push", o)
+	}
+
+	return ret
+}
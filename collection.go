@@ -0,0 +1,94 @@
+package sparse
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Collection holds many Vectors for nearest-neighbour search, caching
+// their magnitudes so queries need not recompute them.
+type Collection struct {
+	ids   []string
+	vecs  []Vector
+	norms []float64
+}
+
+// NewCollection constructs an empty Collection.
+func NewCollection() *Collection {
+	return &Collection{}
+}
+
+// Add v to the Collection under id. The Collection keeps its own copy.
+func (c *Collection) Add(id string, v Vector) {
+	c.ids = append(c.ids, id)
+	c.vecs = append(c.vecs, v.Clone())
+	c.norms = append(c.norms, v.CachedMagnitude())
+}
+
+// Len is the number of Vectors in the Collection.
+func (c *Collection) Len() int {
+	return len(c.vecs)
+}
+
+// Neighbor is a result of a nearest-neighbour search.
+type Neighbor struct {
+	ID       string
+	Distance float64
+}
+
+// neighborHeap is a max-heap of Neighbors by Distance, used to keep the
+// nearest k results seen so far.
+type neighborHeap []Neighbor
+
+func (h neighborHeap) Len() int           { return len(h) }
+func (h neighborHeap) Less(i, j int) bool { return h[i].Distance > h[j].Distance }
+func (h neighborHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *neighborHeap) Push(x any)        { *h = append(*h, x.(Neighbor)) }
+func (h *neighborHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// NearestK returns the k Vectors in the Collection nearest to query
+// under metric, nearest first. Vectors whose distance is undefined, such
+// as zero vectors under Cosine, are skipped. It returns nil unless k is
+// positive.
+func (c *Collection) NearestK(query Vector, k int, metric Metric) []Neighbor {
+	if k <= 0 {
+		return nil
+	}
+
+	qnorm := query.CachedMagnitude()
+	h := make(neighborHeap, 0, min(k, len(c.vecs)))
+	for i, v := range c.vecs {
+		var d float64
+		switch metric {
+		case Cosine:
+			d = 1 - Dot(query, v)/(qnorm*c.norms[i])
+		case Euclidean:
+			d = math.Sqrt(max(qnorm*qnorm+c.norms[i]*c.norms[i]-2*Dot(query, v), 0))
+		default:
+			d = Distance(query, v, metric)
+		}
+
+		if math.IsNaN(d) {
+			continue
+		}
+
+		if len(h) < k {
+			heap.Push(&h, Neighbor{ID: c.ids[i], Distance: d})
+		} else if d < h[0].Distance {
+			h[0] = Neighbor{ID: c.ids[i], Distance: d}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	ret := make([]Neighbor, len(h))
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(&h).(Neighbor)
+	}
+
+	return ret
+}
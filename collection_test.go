@@ -0,0 +1,27 @@
+package sparse
+
+import "testing"
+
+func TestCollectionNearestK(t *testing.T) {
+	c := NewCollection()
+	c.Add("x", NewVectorFromArray([]float64{1, 0}))
+	c.Add("y", NewVectorFromArray([]float64{0, 1}))
+	c.Add("zero", NewVector(2))
+
+	query := NewVectorFromArray([]float64{1, 0.1})
+	for _, k := range []int{-1, 0} {
+		if got := c.NearestK(query, k, Cosine); got != nil {
+			t.Errorf("NearestK(k=%d) = %v, want nil", k, got)
+		}
+	}
+
+	got := c.NearestK(query, 1<<40, Cosine)
+	if len(got) != 2 || got[0].ID != "x" || got[1].ID != "y" {
+		t.Fatalf("NearestK(k=1<<40) = %v, want x then y", got)
+	}
+
+	got = c.NearestK(query, 1, Euclidean)
+	if len(got) != 1 || got[0].ID != "x" {
+		t.Fatalf("NearestK(k=1, Euclidean) = %v, want x", got)
+	}
+}
@@ -0,0 +1,186 @@
+package sparse
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// CompressedVector is an immutable sparse vector stored as a sorted
+// slice of indices with a parallel slice of values. Its contiguous
+// layout makes Dot, Add and iteration merge-style passes that are
+// markedly faster than Vector's map for vectors with many entries, at
+// the cost of O(nnz) updates. Build one with Vector.Compress or
+// NewCompressedVector. The zero value is an empty, zero-dimensional
+// CompressedVector.
+type CompressedVector struct {
+	dim     int
+	indices []int
+	values  []float64
+}
+
+// Compress converts the Vector to a CompressedVector, leaving out stored
+// zeros and entries outside its dimensions.
+func (v Vector) Compress() CompressedVector {
+	ret := CompressedVector{dim: v.dim}
+	ret.indices = make([]int, 0, len(v.data))
+	for n, d := range v.data {
+		if d != 0 && n >= 0 && n < v.dim {
+			ret.indices = append(ret.indices, n)
+		}
+	}
+
+	slices.Sort(ret.indices)
+	ret.values = make([]float64, len(ret.indices))
+	for i, n := range ret.indices {
+		ret.values[i] = v.data[n]
+	}
+
+	return ret
+}
+
+// NewCompressedVector constructs a CompressedVector with dim number of
+// dimensions from parallel slices of indices and values, which it
+// copies. Indices must be strictly ascending and within the
+// dimensions, or it returns ErrOutOfRange; zero values are left out.
+func NewCompressedVector(dim int, indices []int, values []float64) (CompressedVector, error) {
+	if len(indices) != len(values) {
+		return CompressedVector{}, fmt.Errorf("%w: %d indices but %d values", ErrDimensionMismatch, len(indices), len(values))
+	}
+
+	ret := CompressedVector{dim: dim}
+	for i, n := range indices {
+		if n < 0 || n >= dim {
+			return CompressedVector{}, fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, dim)
+		} else if i > 0 && n <= indices[i-1] {
+			return CompressedVector{}, fmt.Errorf("%w: indices not ascending at %d", ErrOutOfRange, n)
+		}
+
+		if values[i] != 0 {
+			ret.indices = append(ret.indices, n)
+			ret.values = append(ret.values, values[i])
+		}
+	}
+
+	return ret, nil
+}
+
+// Decompress converts the CompressedVector back to a Vector.
+func (c CompressedVector) Decompress() Vector {
+	ret := NewVector(c.dim, WithCapacity(len(c.indices)))
+	for i, n := range c.indices {
+		ret.data[n] = c.values[i]
+	}
+
+	return ret
+}
+
+// Size is the dimensionality of the vector.
+func (c CompressedVector) Size() int {
+	return c.dim
+}
+
+// NNZ is the number of non-zero entries.
+func (c CompressedVector) NNZ() int {
+	return len(c.indices)
+}
+
+// Get data from the n'th dimension, by binary search.
+func (c CompressedVector) Get(n int) float64 {
+	if i, ok := slices.BinarySearch(c.indices, n); ok {
+		return c.values[i]
+	}
+
+	return 0
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry, in ascending index order.
+func (c CompressedVector) NonZeros() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i, n := range c.indices {
+			if !yield(n, c.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Magnitude (scalar) of the vector.
+func (c CompressedVector) Magnitude() float64 {
+	ret := float64(0)
+	for _, d := range c.values {
+		ret += d * d
+	}
+
+	return math.Sqrt(ret)
+}
+
+// Times a scalar, returning a new CompressedVector.
+func (c CompressedVector) Times(scalar float64) CompressedVector {
+	if scalar == 0 {
+		return CompressedVector{dim: c.dim}
+	}
+
+	ret := CompressedVector{dim: c.dim, indices: c.indices, values: make([]float64, len(c.values))}
+	for i, d := range c.values {
+		ret.values[i] = d * scalar
+	}
+
+	return ret
+}
+
+// Dot product with another CompressedVector, in a single merge of their
+// indices. Like Dot, it does not check that their dimensions match.
+func (c CompressedVector) Dot(other CompressedVector) float64 {
+	ret := float64(0)
+	i, j := 0, 0
+	for i < len(c.indices) && j < len(other.indices) {
+		switch a, b := c.indices[i], other.indices[j]; {
+		case a < b:
+			i++
+		case a > b:
+			j++
+		default:
+			ret += c.values[i] * other.values[j]
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
+
+// Add another CompressedVector, in a single merge of their indices. The
+// result takes the larger dimensionality of the two, and leaves out
+// entries that cancel.
+func (c CompressedVector) Add(other CompressedVector) CompressedVector {
+	ret := CompressedVector{dim: max(c.dim, other.dim)}
+	ret.indices = make([]int, 0, len(c.indices)+len(other.indices))
+	ret.values = make([]float64, 0, len(c.indices)+len(other.indices))
+	push := func(n int, d float64) {
+		if d != 0 {
+			ret.indices = append(ret.indices, n)
+			ret.values = append(ret.values, d)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(c.indices) || j < len(other.indices) {
+		switch {
+		case j == len(other.indices) || (i < len(c.indices) && c.indices[i] < other.indices[j]):
+			push(c.indices[i], c.values[i])
+			i++
+		case i == len(c.indices) || other.indices[j] < c.indices[i]:
+			push(other.indices[j], other.values[j])
+			j++
+		default:
+			push(c.indices[i], c.values[i]+other.values[j])
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,189 @@
+package sparse
+
+import (
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+)
+
+// CompressedVector32 is a CompressedVector with float32 values and
+// int32 indices, for embeddings and other Vectors whose values need no
+// more precision. Halving the width of both slices halves the memory
+// its kernels stream through, and they are unrolled over independent
+// accumulators so the compiler can keep several products in flight.
+// Build one with Vector.Compress32. The zero value is an empty,
+// zero-dimensional CompressedVector32.
+type CompressedVector32 struct {
+	dim     int
+	indices []int32
+	values  []float32
+}
+
+// Compress32 converts the Vector to a CompressedVector32, rounding its
+// values to float32 and leaving out those that round to zero, stored
+// zeros and entries outside its dimensions. It panics if the Vector has
+// more dimensions than int32 indices can address.
+func (v Vector) Compress32() CompressedVector32 {
+	if v.dim > math.MaxInt32 {
+		panic(fmt.Errorf("%w: dim %d exceeds int32 indices", ErrOutOfRange, v.dim))
+	}
+
+	c := v.Compress()
+	ret := CompressedVector32{dim: c.dim, indices: make([]int32, 0, len(c.indices)), values: make([]float32, 0, len(c.values))}
+	for i, n := range c.indices {
+		if d := float32(c.values[i]); d != 0 {
+			ret.indices = append(ret.indices, int32(n))
+			ret.values = append(ret.values, d)
+		}
+	}
+
+	return ret
+}
+
+// Decompress converts the CompressedVector32 back to a Vector.
+func (c CompressedVector32) Decompress() Vector {
+	ret := NewVector(c.dim, WithCapacity(len(c.indices)))
+	for i, n := range c.indices {
+		ret.data[int(n)] = float64(c.values[i])
+	}
+
+	return ret
+}
+
+// Size is the dimensionality of the vector.
+func (c CompressedVector32) Size() int {
+	return c.dim
+}
+
+// NNZ is the number of non-zero entries.
+func (c CompressedVector32) NNZ() int {
+	return len(c.indices)
+}
+
+// Get data from the n'th dimension, by binary search.
+func (c CompressedVector32) Get(n int) float32 {
+	if n < 0 || n > math.MaxInt32 {
+		return 0
+	} else if i, ok := slices.BinarySearch(c.indices, int32(n)); ok {
+		return c.values[i]
+	}
+
+	return 0
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry, in ascending index order.
+func (c CompressedVector32) NonZeros() iter.Seq2[int, float32] {
+	return func(yield func(int, float32) bool) {
+		for i, n := range c.indices {
+			if !yield(int(n), c.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Magnitude (scalar) of the vector, accumulated in float64.
+func (c CompressedVector32) Magnitude() float64 {
+	ret := float64(0)
+	for _, d := range c.values {
+		ret += float64(d) * float64(d)
+	}
+
+	return math.Sqrt(ret)
+}
+
+// Dot product with another CompressedVector32, in a single merge of
+// their indices. Like Dot, it does not check that their dimensions
+// match.
+func (c CompressedVector32) Dot(other CompressedVector32) float32 {
+	ret := float32(0)
+	i, j := 0, 0
+	for i < len(c.indices) && j < len(other.indices) {
+		switch a, b := c.indices[i], other.indices[j]; {
+		case a < b:
+			i++
+		case a > b:
+			j++
+		default:
+			ret += c.values[i] * other.values[j]
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
+
+// DotDense is the Dot product with a dense float32 slice, as when
+// scoring against a dense query embedding. It panics unless the slice
+// covers every index the CompressedVector32 stores.
+func (c CompressedVector32) DotDense(dense []float32) float32 {
+	var s0, s1, s2, s3 float32
+	indices, values := c.indices, c.values[:len(c.indices)]
+	i := 0
+	for ; i+4 <= len(indices); i += 4 {
+		s0 += values[i] * dense[indices[i]]
+		s1 += values[i+1] * dense[indices[i+1]]
+		s2 += values[i+2] * dense[indices[i+2]]
+		s3 += values[i+3] * dense[indices[i+3]]
+	}
+
+	for ; i < len(indices); i++ {
+		s0 += values[i] * dense[indices[i]]
+	}
+
+	return (s0 + s1) + (s2 + s3)
+}
+
+// AxpyDense adds alpha times the CompressedVector32 to a dense float32
+// slice in place. It panics unless the slice covers every index the
+// CompressedVector32 stores.
+func (c CompressedVector32) AxpyDense(alpha float32, dense []float32) {
+	indices, values := c.indices, c.values[:len(c.indices)]
+	i := 0
+	for ; i+4 <= len(indices); i += 4 {
+		dense[indices[i]] += alpha * values[i]
+		dense[indices[i+1]] += alpha * values[i+1]
+		dense[indices[i+2]] += alpha * values[i+2]
+		dense[indices[i+3]] += alpha * values[i+3]
+	}
+
+	for ; i < len(indices); i++ {
+		dense[indices[i]] += alpha * values[i]
+	}
+}
+
+// Add another CompressedVector32, in a single merge of their indices.
+// The result takes the larger dimensionality of the two, and leaves out
+// entries that cancel.
+func (c CompressedVector32) Add(other CompressedVector32) CompressedVector32 {
+	ret := CompressedVector32{dim: max(c.dim, other.dim)}
+	ret.indices = make([]int32, 0, len(c.indices)+len(other.indices))
+	ret.values = make([]float32, 0, len(c.indices)+len(other.indices))
+	push := func(n int32, d float32) {
+		if d != 0 {
+			ret.indices = append(ret.indices, n)
+			ret.values = append(ret.values, d)
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(c.indices) || j < len(other.indices) {
+		switch {
+		case j == len(other.indices) || (i < len(c.indices) && c.indices[i] < other.indices[j]):
+			push(c.indices[i], c.values[i])
+			i++
+		case i == len(c.indices) || other.indices[j] < c.indices[i]:
+			push(other.indices[j], other.values[j])
+			j++
+		default:
+			push(c.indices[i], c.values[i]+other.values[j])
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,189 @@
+package sparse
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// CSVLayout is how a CSV file lays out a matrix of Vectors.
+type CSVLayout int
+
+const (
+	// CSVWide is one Vector per record and one dimension per field, as
+	// in a dense export of a dataframe. Empty fields are zero.
+	CSVWide CSVLayout = iota
+
+	// CSVLong is one non-zero entry per record, as the 0-based row,
+	// column and value triplet of a long-format export.
+	CSVLong
+)
+
+// CSVOptions configures FromCSV and WriteCSV. The zero value is a wide,
+// comma-separated file without a header.
+type CSVOptions struct {
+	// Layout is the layout of the file.
+	Layout CSVLayout
+
+	// Header is whether the file starts with a header record, which
+	// FromCSV skips and WriteCSV writes.
+	Header bool
+
+	// Comma is the field delimiter, or ',' if zero.
+	Comma rune
+
+	// MaxRows bounds the rows FromCSV returns for a long file, or is
+	// DefaultCSVMaxRows if zero, so that a corrupt row index cannot
+	// have it allocate without limit.
+	MaxRows int
+}
+
+// DefaultCSVMaxRows is the MaxRows of CSVOptions that leave it zero.
+const DefaultCSVMaxRows = 1 << 24
+
+// FromCSV reads a CSV file of opts.Layout into Vectors, one per row.
+// Every Vector has as many dimensions as the widest row of a wide file,
+// or as the largest column of a long one plus one. A long file's
+// entries may come in any order, and rows it gives no entries are
+// empty; it returns ErrDuplicateIndex for a repeated row and column,
+// and ErrOutOfRange for a negative index, a row from opts.MaxRows on or
+// a column from math.MaxInt on.
+func FromCSV(r io.Reader, opts CSVOptions) ([]Vector, error) {
+	cr := csv.NewReader(r)
+	if opts.Comma != 0 {
+		cr.Comma = opts.Comma
+	}
+
+	if opts.Layout == CSVLong {
+		cr.FieldsPerRecord = 3
+	} else {
+		cr.FieldsPerRecord = -1
+	}
+
+	cr.ReuseRecord = true
+	if opts.Header {
+		if _, err := cr.Read(); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("sparse: csv: %w", err)
+		}
+	}
+
+	maxRows := opts.MaxRows
+	if maxRows == 0 {
+		maxRows = DefaultCSVMaxRows
+	}
+
+	var rows []Vector
+	dim := 0
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("sparse: csv: %w", err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		if opts.Layout == CSVLong {
+			i, err1 := strconv.Atoi(strings.TrimSpace(record[0]))
+			n, err2 := strconv.Atoi(strings.TrimSpace(record[1]))
+			d, err3 := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+			if err := errors.Join(err1, err2, err3); err != nil {
+				return nil, fmt.Errorf("sparse: csv: line %d: %w", line, err)
+			} else if i < 0 || n < 0 || i >= maxRows || n == math.MaxInt {
+				return nil, fmt.Errorf("%w: csv line %d: entry (%d, %d)", ErrOutOfRange, line, i, n)
+			}
+
+			for len(rows) <= i {
+				rows = append(rows, NewVector(0))
+			}
+
+			if rows[i].Has(n) {
+				return nil, fmt.Errorf("%w: csv line %d: (%d, %d)", ErrDuplicateIndex, line, i, n)
+			}
+
+			rows[i].Set(n, d)
+			dim = max(dim, n+1)
+			continue
+		}
+
+		row := NewVector(len(record))
+		for n, field := range record {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+
+			d, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("sparse: csv: line %d: %w", line, err)
+			} else if d != 0 {
+				row.Set(n, d)
+			}
+		}
+
+		rows = append(rows, row)
+		dim = max(dim, len(record))
+	}
+
+	for i := range rows {
+		rows[i].Grow(dim)
+	}
+
+	return rows, nil
+}
+
+// WriteCSV writes rows as a CSV file of opts.Layout. A wide file has as
+// many fields per record as the largest row dimensionality, numbered
+// from 0 in its header; a long file has a "row,col,value" header and
+// records in row and column order. Entries stored outside a row's
+// dimensions are left out.
+func WriteCSV(w io.Writer, rows []Vector, opts CSVOptions) error {
+	cw := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		cw.Comma = opts.Comma
+	}
+
+	dim := 0
+	for _, row := range rows {
+		dim = max(dim, row.dim)
+	}
+
+	if opts.Header && opts.Layout == CSVLong {
+		cw.Write([]string{"row", "col", "value"})
+	} else if opts.Header {
+		header := make([]string, dim)
+		for n := range header {
+			header[n] = strconv.Itoa(n)
+		}
+
+		cw.Write(header)
+	}
+
+	record := make([]string, dim)
+	for i, row := range rows {
+		if opts.Layout == CSVLong {
+			for n, d := range row.SortedNonZeros() {
+				if n >= 0 && n < row.dim {
+					cw.Write([]string{strconv.Itoa(i), strconv.Itoa(n), strconv.FormatFloat(d, 'g', -1, 64)})
+				}
+			}
+
+			continue
+		}
+
+		for n := range record {
+			record[n] = "0"
+			if d := row.data[n]; n < row.dim && d != 0 {
+				record[n] = strconv.FormatFloat(d, 'g', -1, 64)
+			}
+		}
+
+		cw.Write(record)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,49 @@
+package sparse
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFromCSVLong(t *testing.T) {
+	rows, err := FromCSV(strings.NewReader("row,col,value\n2,1,5\n0,3,-1\n"), CSVOptions{Layout: CSVLong, Header: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	if rows[2].Get(1) != 5 || rows[0].Get(3) != -1 || rows[1].NNZ() != 0 || rows[1].Size() != 4 {
+		t.Errorf("got rows %v", rows)
+	}
+}
+
+func TestFromCSVLongBounds(t *testing.T) {
+	for _, entry := range []string{"-1,0,1", "0,-1,1", "16,0,1", "0," + strconv.Itoa(math.MaxInt) + ",1"} {
+		if _, err := FromCSV(strings.NewReader(entry+"\n"), CSVOptions{Layout: CSVLong, MaxRows: 16}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("%q: got %v, want ErrOutOfRange", entry, err)
+		}
+	}
+
+	if _, err := FromCSV(strings.NewReader("15,0,1\n"), CSVOptions{Layout: CSVLong, MaxRows: 16}); err != nil {
+		t.Errorf("got %v for the last row allowed", err)
+	}
+}
+
+func TestFromCSVWideRoundTrip(t *testing.T) {
+	rows := []Vector{NewVectorFromArray([]float64{1, 0, 2.5}), NewVectorFromArray([]float64{0, -3})}
+	var b strings.Builder
+	if err := WriteCSV(&b, rows, CSVOptions{Header: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromCSV(strings.NewReader(b.String()), CSVOptions{Header: true})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(got) != 2 || !Equal(got[0], rows[0]) || got[1].Get(1) != -3 || got[1].Size() != 3 {
+		t.Errorf("got %v, want %v", got, rows)
+	}
+}
@@ -0,0 +1,75 @@
+package sparse
+
+import "math"
+
+// rescaleBelow is the scale factor under which a DecayAccumulator folds
+// its scale into its entries, well before divisions by it overflow.
+const rescaleBelow = 0x1p-256
+
+// DecayAccumulator maintains an exponentially time-decayed sum
+// v ← λ·v + x. It stores a global scale factor alongside unscaled
+// entries, so each update costs O(nnz(x)) rather than O(nnz(v)).
+type DecayAccumulator struct {
+	lambda float64
+	scale  float64
+	dim    int
+	data   map[int]float64 // entries divided by scale
+}
+
+// NewDecayAccumulator constructs a DecayAccumulator that decays by lambda
+// per update. It panics unless lambda is in (0, 1].
+func NewDecayAccumulator(lambda float64) *DecayAccumulator {
+	if !(lambda > 0 && lambda <= 1) {
+		panic("sparse: decay factor must be in (0, 1]")
+	}
+
+	return &DecayAccumulator{lambda: lambda, scale: 1, data: map[int]float64{}}
+}
+
+// Update decays the accumulated Vector by λ and adds x to it.
+func (a *DecayAccumulator) Update(x Vector) {
+	a.scale *= a.lambda
+	if a.scale < rescaleBelow {
+		a.rescale()
+	}
+
+	a.dim = max(a.dim, x.dim)
+	for n, d := range x.data {
+		a.data[n] += d / a.scale
+	}
+}
+
+// Decay the accumulated Vector by λ without adding to it, as when a time
+// step passes without events.
+func (a *DecayAccumulator) Decay() {
+	a.Update(Vector{})
+}
+
+// rescale folds the scale factor into the entries, dropping those that
+// have decayed to zero.
+func (a *DecayAccumulator) rescale() {
+	for n, d := range a.data {
+		if d *= a.scale; d == 0 || math.IsInf(d, 0) {
+			delete(a.data, n)
+		} else {
+			a.data[n] = d
+		}
+	}
+
+	a.scale = 1
+}
+
+// Get the accumulated value on the n'th dimension.
+func (a *DecayAccumulator) Get(n int) float64 {
+	return a.data[n] * a.scale
+}
+
+// Vector materializes the accumulated Vector.
+func (a *DecayAccumulator) Vector() Vector {
+	ret := NewVector(a.dim, WithCapacity(len(a.data)))
+	for n, d := range a.data {
+		ret.data[n] = d * a.scale
+	}
+
+	return ret
+}
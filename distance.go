@@ -0,0 +1,110 @@
+package sparse
+
+import (
+	"fmt"
+	"math"
+)
+
+// Metric is a distance function between Vectors.
+type Metric int
+
+const (
+	// Euclidean is the L2 distance.
+	Euclidean Metric = iota
+
+	// Manhattan is the L1 distance.
+	Manhattan
+
+	// Chebyshev is the L∞ distance.
+	Chebyshev
+
+	// Jaccard is one minus the Jaccard index of the Vectors' supports,
+	// treating them as sets of non-zero indices.
+	Jaccard
+
+	// WeightedJaccard is one minus the ratio of the sums of the
+	// element-wise minima and maxima of the Vectors, which should be
+	// non-negative.
+	WeightedJaccard
+
+	// Cosine is one minus the cosine similarity of the Vectors, as
+	// CosineDistance.
+	Cosine
+
+	// Angular is the angle between the Vectors as a fraction of π, as
+	// AngularDistance. Unlike Cosine it satisfies the triangle
+	// inequality.
+	Angular
+)
+
+// Distance between two Vectors under metric, visiting only the indices
+// stored in either. Like Dot, it does not check that their dimensions
+// match. It panics on an unknown Metric.
+func Distance(v1 Vector, v2 Vector, metric Metric) float64 {
+	switch metric {
+	case Cosine:
+		return CosineDistance(v1, v2)
+	case Angular:
+		return AngularDistance(v1, v2)
+	case Euclidean:
+		sum := float64(0)
+		union(v1, v2, func(n int, d1 float64, d2 float64) {
+			sum += (d1 - d2) * (d1 - d2)
+		})
+
+		return math.Sqrt(sum)
+	}
+
+	var a, b float64
+	switch metric {
+	case Manhattan:
+		union(v1, v2, func(n int, d1 float64, d2 float64) {
+			a += math.Abs(d1 - d2)
+		})
+
+		return a
+	case Chebyshev:
+		union(v1, v2, func(n int, d1 float64, d2 float64) {
+			a = max(a, math.Abs(d1-d2))
+		})
+
+		return a
+	case Jaccard:
+		union(v1, v2, func(n int, d1 float64, d2 float64) {
+			if d1 != 0 && d2 != 0 {
+				a++
+			}
+
+			if d1 != 0 || d2 != 0 {
+				b++
+			}
+		})
+	case WeightedJaccard:
+		union(v1, v2, func(n int, d1 float64, d2 float64) {
+			a += min(d1, d2)
+			b += max(d1, d2)
+		})
+	default:
+		panic(fmt.Sprintf("sparse: unknown metric %d", metric))
+	}
+
+	if b == 0 {
+		return 0
+	}
+
+	return 1 - a/b
+}
+
+// union calls fn for every index stored in either v1 or v2, in no
+// particular order, with zero for a value one of them does not store.
+func union(v1 Vector, v2 Vector, fn func(n int, d1 float64, d2 float64)) {
+	for n, d1 := range v1.data {
+		fn(n, d1, v2.data[n])
+	}
+
+	for n, d2 := range v2.data {
+		if _, ok := v1.data[n]; !ok {
+			fn(n, 0, d2)
+		}
+	}
+}
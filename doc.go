@@ -0,0 +1,19 @@
+// Package sparse implements sparse vectors, which store only their
+// non-zero dimensions.
+//
+// # Mutation and aliasing
+//
+// A Vector is a small value holding its dimensionality and a reference
+// to its entries. Every operation that mutates a Vector (Set,
+// SetChecked, Load, Grow, Remove, Clear and the InPlace arithmetic) has
+// a pointer receiver, and every operation with a value receiver leaves
+// the Vector unchanged.
+//
+// Copying a Vector, by assignment or by passing it as an argument,
+// yields a second handle onto the same entries: entries written through
+// one copy are visible through the other, but dimensionality changes
+// made by Grow or auto-grow are not. Use Clone for an independent
+// copy. Operations that return a Vector, such as Add, Times and
+// Concat, return one with its own entries and never modify their
+// operands, unless documented otherwise.
+package sparse
@@ -0,0 +1,92 @@
+package sparse
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+// randomVector returns a Vector of dim dimensions with nnz random
+// entries.
+func randomVector(r *rand.Rand, dim int, nnz int) Vector {
+	ret := NewVector(dim, WithCapacity(nnz))
+	for ret.NNZ() < nnz {
+		ret.Set(r.IntN(dim), r.NormFloat64())
+	}
+
+	return ret
+}
+
+func TestDotIntersects(t *testing.T) {
+	// Equal sizes once made Dot compute v1·v1.
+	v1 := NewVectorFromArray([]float64{1, 2, 0, 0})
+	v2 := NewVectorFromArray([]float64{0, 3, 4, 0})
+	if got := Dot(v1, v2); got != 6 {
+		t.Fatalf("Dot(v1, v2) = %g, want 6", got)
+	} else if got := Dot(v2, v1); got != 6 {
+		t.Fatalf("Dot(v2, v1) = %g, want 6", got)
+	}
+
+	r := rand.New(rand.NewPCG(1, 1))
+	for range 100 {
+		a, b := randomVector(r, 50, r.IntN(20)), randomVector(r, 50, r.IntN(20))
+		want := float64(0)
+		for n := range 50 {
+			want += a.Get(n) * b.Get(n)
+		}
+
+		if got := Dot(a, b); !approxEqual(got, want) {
+			t.Fatalf("Dot() = %g, want %g", got, want)
+		} else if got := a.Compress().Dot(b.Compress()); !approxEqual(got, want) {
+			t.Fatalf("CompressedVector.Dot() = %g, want %g", got, want)
+		}
+	}
+}
+
+func approxEqual(a float64, b float64) bool {
+	return a == b || (a-b)*(a-b) <= 1e-20*(a*a+b*b)
+}
+
+func BenchmarkDot(b *testing.B) {
+	for _, nnz := range []int{10, 100, 1000, 10000} {
+		r := rand.New(rand.NewPCG(1, uint64(nnz)))
+		v1, v2 := randomVector(r, 100*nnz, nnz), randomVector(r, 100*nnz, nnz)
+		b.Run(fmt.Sprintf("nnz=%d", nnz), func(b *testing.B) {
+			for b.Loop() {
+				Dot(v1, v2)
+			}
+		})
+	}
+}
+
+// BenchmarkDotSkewed shows that Dot's cost follows the smaller operand
+// in either argument order.
+func BenchmarkDotSkewed(b *testing.B) {
+	r := rand.New(rand.NewPCG(2, 2))
+	small, large := randomVector(r, 1_000_000, 10), randomVector(r, 1_000_000, 100_000)
+	b.Run("small-large", func(b *testing.B) {
+		for b.Loop() {
+			Dot(small, large)
+		}
+	})
+
+	b.Run("large-small", func(b *testing.B) {
+		for b.Loop() {
+			Dot(large, small)
+		}
+	})
+}
+
+// BenchmarkDotCompressed is the merge over sorted indices that Dot's
+// map probes are measured against.
+func BenchmarkDotCompressed(b *testing.B) {
+	for _, nnz := range []int{10, 100, 1000, 10000} {
+		r := rand.New(rand.NewPCG(1, uint64(nnz)))
+		v1, v2 := randomVector(r, 100*nnz, nnz).Compress(), randomVector(r, 100*nnz, nnz).Compress()
+		b.Run(fmt.Sprintf("nnz=%d", nnz), func(b *testing.B) {
+			for b.Loop() {
+				v1.Dot(v2)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package sparse
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// binaryVersion is the leading byte of the binary encoding.
+const binaryVersion = 1
+
+var errMalformedBinary = errors.New("sparse: malformed binary encoding")
+
+// MarshalBinary implements encoding.BinaryMarshaler, which also lets
+// encoding/gob encode Vectors. The encoding is a version byte, the
+// uvarint dim and entry count, then each stored entry in ascending index
+// order as a uvarint gap from the previous index followed by its
+// little-endian float64 value. It returns ErrOutOfRange if the Vector
+// stores entries outside its dimensions, which the encoding cannot
+// represent.
+func (v Vector) MarshalBinary() ([]byte, error) {
+	return v.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender, appending the
+// encoding of MarshalBinary to buf.
+func (v Vector) AppendBinary(buf []byte) ([]byte, error) {
+	if err := checkInRange(v); err != nil {
+		return nil, err
+	}
+
+	indices := make([]int, 0, len(v.data))
+	for n := range v.data {
+		indices = append(indices, n)
+	}
+
+	slices.Sort(indices)
+	buf = slices.Grow(buf, 1+2*binary.MaxVarintLen64+len(indices)*10)
+	buf = append(buf, binaryVersion)
+	buf = binary.AppendUvarint(buf, uint64(v.dim))
+	buf = binary.AppendUvarint(buf, uint64(len(indices)))
+	prev := -1
+	for _, n := range indices {
+		buf = binary.AppendUvarint(buf, uint64(n-prev-1))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.data[n]))
+		prev = n
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, and so
+// encoding/gob decoding, replacing the contents of v but keeping the
+// options it was constructed with.
+func (v *Vector) UnmarshalBinary(buf []byte) error {
+	if len(buf) == 0 || buf[0] != binaryVersion {
+		return errMalformedBinary
+	}
+
+	buf = buf[1:]
+	next := func() (uint64, bool) {
+		x, k := binary.Uvarint(buf)
+		if k <= 0 {
+			return 0, false
+		}
+
+		buf = buf[k:]
+		return x, true
+	}
+
+	dim, ok1 := next()
+	count, ok2 := next()
+	if !ok1 || !ok2 || dim > math.MaxInt || count > dim {
+		return errMalformedBinary
+	}
+
+	ret := v.blank(int(dim), 0)
+	n := -1
+	for ; count > 0; count-- {
+		gap, ok := next()
+		if !ok || len(buf) < 8 || gap >= dim-uint64(n+1) {
+			return errMalformedBinary
+		}
+
+		n += int(gap) + 1
+		ret.data[n] = math.Float64frombits(binary.LittleEndian.Uint64(buf))
+		buf = buf[8:]
+	}
+
+	if len(buf) != 0 {
+		return errMalformedBinary
+	}
+
+	*v = ret
+	return nil
+}
+
+// vectorJSON is the JSON form of a Vector.
+type vectorJSON struct {
+	Dim  int             `json:"dim"`
+	Data map[int]float64 `json:"data"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding the Vector as
+// {"dim": N, "data": {"3": 1.5, ...}} with one member per stored entry.
+// It returns ErrOutOfRange if the Vector stores entries outside its
+// dimensions, and an error for NaN or infinite values, which JSON cannot
+// represent.
+func (v Vector) MarshalJSON() ([]byte, error) {
+	if err := checkInRange(v); err != nil {
+		return nil, err
+	}
+
+	vj := vectorJSON{Dim: v.dim, Data: v.data}
+	if vj.Data == nil {
+		vj.Data = map[int]float64{}
+	}
+
+	return json.Marshal(vj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the layout of
+// MarshalJSON and replacing the contents of v but keeping the options
+// it was constructed with. It returns ErrOutOfRange if an entry falls
+// outside the given dim, unless v's Bounds are AutoGrow, which grows it
+// to fit.
+func (v *Vector) UnmarshalJSON(b []byte) error {
+	var vj vectorJSON
+	if err := json.Unmarshal(b, &vj); err != nil {
+		return err
+	}
+
+	if vj.Dim < 0 {
+		return fmt.Errorf("%w: negative dim %d", ErrOutOfRange, vj.Dim)
+	}
+
+	ret := v.blank(vj.Dim, len(vj.Data))
+	for n, d := range vj.Data {
+		if err := ret.admit(n); err != nil {
+			return err
+		}
+
+		ret.data[n] = d
+	}
+
+	*v = ret
+	return nil
+}
+
+// blank returns an empty Vector of dim dimensions with v's options, for
+// decoding into v.
+func (v *Vector) blank(dim int, capacity int) Vector {
+	return Vector{dim: dim, data: v.opts.newMap(capacity), opts: v.opts, norms: v.opts.newNormCache()}
+}
+
+// admit checks that a decoded entry on the n'th dimension fits the
+// Vector, growing it to fit under AutoGrow and otherwise returning
+// ErrOutOfRange.
+func (v *Vector) admit(n int) error {
+	if n >= 0 && n >= v.dim && v.opts.boundsMode() == AutoGrow {
+		v.dim = n + 1
+	} else if n < 0 || n >= v.dim {
+		return fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+	}
+
+	return nil
+}
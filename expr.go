@@ -0,0 +1,81 @@
+package sparse
+
+// Expression is a lazily evaluated linear combination of Vectors, built
+// by chaining operations from Expr. Eval computes it in one pass over
+// each operand's entries into a single new Vector, where chaining Add,
+// Times and the like would allocate a Vector per step. Expressions are
+// immutable: each operation returns a new one.
+type Expression struct {
+	terms []term
+}
+
+// term is one Vector of an Expression and its coefficient.
+type term struct {
+	alpha float64
+	v     Vector
+}
+
+// Expr starts an Expression with v.
+func Expr(v Vector) Expression {
+	return Expression{terms: []term{{alpha: 1, v: v}}}
+}
+
+// with returns a copy of the Expression with t appended, not sharing
+// its terms.
+func (e Expression) with(t term) Expression {
+	terms := make([]term, len(e.terms), len(e.terms)+1)
+	copy(terms, e.terms)
+	return Expression{terms: append(terms, t)}
+}
+
+// Scale the Expression by scalar.
+func (e Expression) Scale(scalar float64) Expression {
+	ret := Expression{terms: make([]term, len(e.terms))}
+	for i, t := range e.terms {
+		ret.terms[i] = term{alpha: t.alpha * scalar, v: t.v}
+	}
+
+	return ret
+}
+
+// Add v to the Expression.
+func (e Expression) Add(v Vector) Expression {
+	return e.with(term{alpha: 1, v: v})
+}
+
+// AddScaled adds alpha times v to the Expression.
+func (e Expression) AddScaled(alpha float64, v Vector) Expression {
+	return e.with(term{alpha: alpha, v: v})
+}
+
+// Sub subtracts v from the Expression.
+func (e Expression) Sub(v Vector) Expression {
+	return e.with(term{alpha: -1, v: v})
+}
+
+// Eval computes the Expression into a new Vector with the largest
+// dimensionality among its operands and the options of the first, as
+// a Clone of it would have. Like Add, it does not check that the
+// operands' dimensions match, and it keeps entries that cancel out.
+func (e Expression) Eval() Vector {
+	if len(e.terms) == 0 {
+		return Vector{}
+	}
+
+	first := e.terms[0].v
+	ret := Vector{opts: first.opts.withoutObserver(), norms: first.opts.newNormCache()}
+	capacity := 0
+	for _, t := range e.terms {
+		ret.dim = max(ret.dim, t.v.dim)
+		capacity = max(capacity, len(t.v.data))
+	}
+
+	ret.data = first.opts.newMap(capacity)
+	for _, t := range e.terms {
+		for n, d := range t.v.data {
+			ret.data[n] += t.alpha * d
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,61 @@
+package sparse
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter. The %v and %s verbs print the same
+// index-sorted form as String, and %e, %E, %f, %F, %g, %G, %x, %X and
+// %b do too with each value formatted by that verb. Width, precision
+// and the '+', '-', ' ' and '0' flags apply to each value, as fmt
+// applies them to the elements of a slice.
+//
+// The '#' flag prints the Vector densely instead, as a bracketed list
+// of all its values, e.g. %#.1f prints "[0.0 0.5 0.0 -2.0]". This suits
+// small Vectors.
+func (v Vector) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's':
+		verb = 'g'
+	case 'e', 'E', 'f', 'F', 'g', 'G', 'x', 'X', 'b':
+	default:
+		fmt.Fprintf(s, "%%!%c(sparse.Vector=%s)", verb, v.String())
+		return
+	}
+
+	directive := strings.Replace(fmt.FormatString(s, verb), "#", "", 1)
+	if s.Flag('#') {
+		s.Write([]byte{'['})
+		for n, d := range v.ToDense() {
+			if n > 0 {
+				s.Write([]byte{' '})
+			}
+
+			fmt.Fprintf(s, directive, d)
+		}
+
+		s.Write([]byte{']'})
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("dim=")
+	sb.WriteString(strconv.Itoa(v.dim))
+	sb.WriteString(" {")
+	for i, n := range slices.Sorted(maps.Keys(v.data)) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(strconv.Itoa(n))
+		sb.WriteByte(':')
+		fmt.Fprintf(&sb, directive, v.data[n])
+	}
+
+	sb.WriteByte('}')
+	s.Write([]byte(sb.String()))
+}
@@ -0,0 +1,167 @@
+package sparse
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Frame holds named Vectors over a common dimension space, in insertion
+// order, with column-wise operations across them.
+type Frame struct {
+	dim   int
+	names []string
+	rows  []Vector
+	index map[string]int
+}
+
+// NewFrame constructs an empty Frame of Vectors with dim dimensions.
+func NewFrame(dim int) *Frame {
+	return &Frame{dim: dim, index: map[string]int{}}
+}
+
+// Dim is the dimensionality of the Frame's Vectors.
+func (f *Frame) Dim() int {
+	return f.dim
+}
+
+// Len is the number of Vectors in the Frame.
+func (f *Frame) Len() int {
+	return len(f.rows)
+}
+
+// Names returns the names of the Frame's Vectors, in insertion order.
+func (f *Frame) Names() []string {
+	return slices.Clone(f.names)
+}
+
+// Add v to the Frame under name, replacing any Vector of that name. It
+// returns ErrDimensionMismatch unless v has the Frame's dimensionality.
+func (f *Frame) Add(name string, v Vector) error {
+	if v.dim != f.dim {
+		return fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v.dim, f.dim)
+	}
+
+	if i, ok := f.index[name]; ok {
+		f.rows[i] = v
+		return nil
+	}
+
+	f.index[name] = len(f.rows)
+	f.names = append(f.names, name)
+	f.rows = append(f.rows, v)
+	return nil
+}
+
+// Get the Vector named name, and whether the Frame holds one.
+func (f *Frame) Get(name string) (Vector, bool) {
+	i, ok := f.index[name]
+	if !ok {
+		return Vector{}, false
+	}
+
+	return f.rows[i], true
+}
+
+// Rows returns the Frame's Vectors in insertion order, as the rows of a
+// matrix.
+func (f *Frame) Rows() []Vector {
+	return slices.Clone(f.rows)
+}
+
+// Matrix returns the Frame's Vectors as the rows of a CSR Matrix, in
+// insertion order.
+func (f *Frame) Matrix() Matrix {
+	ret := NewMatrixFromRows(f.rows)
+	ret.cols = f.dim
+	return ret
+}
+
+// Aggregate reduces each dimension across the Frame's Vectors with fn,
+// which is called with the dimension's stored values and the number of
+// Vectors that leave it an implicit zero. Zero results are not stored.
+func (f *Frame) Aggregate(fn func(values []float64, zeros int) float64) Vector {
+	columns := map[int][]float64{}
+	for _, v := range f.rows {
+		for n, d := range v.data {
+			columns[n] = append(columns[n], d)
+		}
+	}
+
+	ret := NewVector(f.dim, WithCapacity(len(columns)))
+	for n, values := range columns {
+		if d := fn(values, len(f.rows)-len(values)); d != 0 {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
+
+// Sum of the Frame's Vectors, per dimension.
+func (f *Frame) Sum() Vector {
+	return f.Aggregate(func(values []float64, zeros int) float64 {
+		ret := float64(0)
+		for _, d := range values {
+			ret += d
+		}
+
+		return ret
+	})
+}
+
+// Mean of the Frame's Vectors, per dimension.
+func (f *Frame) Mean() Vector {
+	if len(f.rows) == 0 {
+		return NewVector(f.dim)
+	}
+
+	return f.Sum().Times(1 / float64(len(f.rows)))
+}
+
+// StdDev is the population standard deviation of the Frame's Vectors,
+// per dimension.
+func (f *Frame) StdDev() Vector {
+	return f.Aggregate(func(values []float64, zeros int) float64 {
+		count := float64(len(values) + zeros)
+		mean, sq := float64(0), float64(0)
+		for _, d := range values {
+			mean += d
+			sq += d * d
+		}
+
+		mean /= count
+		return math.Sqrt(max(sq/count-mean*mean, 0))
+	})
+}
+
+// Standardize returns a Frame whose Vectors are scaled to unit standard
+// deviation per dimension. With center, each dimension's mean is
+// subtracted first, which generally densifies the Vectors.
+// Dimensions that do not vary are left unscaled.
+func (f *Frame) Standardize(center bool) *Frame {
+	mean, std := f.Mean(), f.StdDev()
+	ret := NewFrame(f.dim)
+	for i, v := range f.rows {
+		out := NewVector(f.dim)
+		if center {
+			for n := range f.dim {
+				if d := v.data[n] - mean.data[n]; d != 0 {
+					out.data[n] = d
+				}
+			}
+		} else {
+			out = v.Clone()
+		}
+
+		for n, d := range out.data {
+			if s := std.data[n]; s != 0 {
+				out.data[n] = d / s
+			}
+		}
+
+		ret.Add(f.names[i], out)
+	}
+
+	return ret
+}
@@ -0,0 +1,47 @@
+package sparse
+
+// Map returns a Vector with fn applied to each non-zero entry of this
+// one. Implicit zeros are not passed to fn and stay zero, so Map cannot
+// express transforms where f(0) != 0, such as adding a constant; those
+// would densify the Vector. Entries fn maps to zero are removed.
+func (v Vector) Map(fn func(index int, value float64) float64) Vector {
+	ret := v.Clone()
+	for n, d := range ret.data {
+		if d == 0 {
+			continue
+		}
+
+		if d = fn(n, d); d == 0 {
+			delete(ret.data, n)
+		} else {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
+
+// Filter returns a Vector with just the non-zero entries of this one for
+// which pred returns true.
+func (v Vector) Filter(pred func(index int, value float64) bool) Vector {
+	ret := v.Clone()
+	for n, d := range ret.data {
+		if d == 0 || !pred(n, d) {
+			delete(ret.data, n)
+		}
+	}
+
+	return ret
+}
+
+// Reduce folds fn over the non-zero entries of the Vector in ascending
+// index order, starting from init, so that floating-point results are
+// reproducible. Implicit zeros are not passed to fn.
+func (v Vector) Reduce(init float64, fn func(acc float64, index int, value float64) float64) float64 {
+	acc := init
+	for n, d := range v.SortedNonZeros() {
+		acc = fn(acc, n, d)
+	}
+
+	return acc
+}
@@ -0,0 +1,125 @@
+package sparse_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/angadn/sparse"
+	"github.com/angadn/sparse/sparsetest"
+)
+
+// seeds returns the Vectors the fuzz targets seed their corpora with.
+func seeds() []sparse.Vector {
+	return []sparse.Vector{
+		sparse.NewVector(0),
+		sparse.NewVector(8),
+		sparse.NewVectorFromArray([]float64{0, 1.5, 0, -2, 1e-300}),
+		sparse.NewVectorFromArray([]float64{math.MaxFloat64, 0, math.SmallestNonzeroFloat64}),
+	}
+}
+
+func FuzzBinary(f *testing.F) { sparsetest.FuzzBinary(f, seeds()...) }
+
+func FuzzJSON(f *testing.F) { sparsetest.FuzzJSON(f, seeds()...) }
+
+func FuzzText(f *testing.F) { sparsetest.FuzzText(f, seeds()...) }
+
+func FuzzDecoder(f *testing.F) { sparsetest.FuzzDecoder(f, seeds()...) }
+
+func FuzzLibSVM(f *testing.F) { sparsetest.FuzzLibSVM(f, seeds()...) }
+
+func FuzzCSV(f *testing.F) { sparsetest.FuzzCSV(f, seeds()...) }
+
+// FuzzMatrixMarket checks that ReadMatrixMarket returns an error rather
+// than panicking on malformed files, and that the rows it returns have
+// the dimensionality of the size line.
+func FuzzMatrixMarket(f *testing.F) {
+	f.Add("%%MatrixMarket matrix coordinate real general\n2 3 2\n1 1 1.5\n2 3 -2\n")
+	f.Add("%%MatrixMarket matrix coordinate pattern symmetric\n3 3 1\n3 1\n")
+	f.Add("%%MatrixMarket matrix coordinate real general\n-1 3 0\n")
+	f.Fuzz(func(t *testing.T, s string) {
+		rows, err := sparse.ReadMatrixMarket(strings.NewReader(s))
+		if err != nil {
+			return
+		}
+
+		for i := 1; i < len(rows); i++ {
+			if rows[i].Size() != rows[0].Size() {
+				t.Fatalf("row %d has dim %d, but row 0 has %d", i, rows[i].Size(), rows[0].Size())
+			}
+		}
+	})
+}
+
+// FuzzScipyCSR checks that ReadScipyCSR returns an error rather than
+// panicking on malformed .npz files, and that the Matrix it returns
+// writes the same file once read again.
+func FuzzScipyCSR(f *testing.F) {
+	var buf bytes.Buffer
+	if err := sparse.WriteScipyCSR(&buf, sparse.NewMatrixFromRows(seeds())); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(buf.Bytes())
+	f.Add(stored(f, buf.Bytes()))
+	f.Fuzz(func(t *testing.T, b []byte) {
+		m, err := sparse.ReadScipyCSR(bytes.NewReader(b))
+		if err != nil {
+			return
+		}
+
+		var out bytes.Buffer
+		if err := sparse.WriteScipyCSR(&out, m); err != nil {
+			t.Fatalf("writing: %v", err)
+		}
+
+		got, err := sparse.ReadScipyCSR(bytes.NewReader(out.Bytes()))
+		if err != nil {
+			t.Fatalf("re-reading: %v", err)
+		}
+
+		var again bytes.Buffer
+		if err := sparse.WriteScipyCSR(&again, got); err != nil {
+			t.Fatalf("re-writing: %v", err)
+		} else if !bytes.Equal(again.Bytes(), out.Bytes()) {
+			t.Fatal("the Matrix read back writes a different file")
+		}
+	})
+}
+
+// stored rewrites a zip file with its entries uncompressed, so that the
+// fuzzer can mutate the .npy headers inside it directly.
+func stored(f *testing.F, b []byte) []byte {
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, file := range zr.File {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.Name, Method: zip.Store})
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			f.Fatal(err)
+		} else if _, err := io.Copy(w, rc); err != nil {
+			f.Fatal(err)
+		}
+
+		rc.Close()
+	}
+
+	if err := zw.Close(); err != nil {
+		f.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
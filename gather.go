@@ -0,0 +1,61 @@
+package sparse
+
+import "fmt"
+
+// GetMany gathers data from each of the given dimensions, as Get does.
+// The i'th value returned is from the indices[i]'th dimension.
+func (v Vector) GetMany(indices []int) []float64 {
+	ret := make([]float64, len(indices))
+	for i, n := range indices {
+		ret[i] = v.data[n]
+	}
+
+	return ret
+}
+
+// SetMany sets values[i] on the indices[i]'th dimension for each i, as
+// Set does and panicking on the writes Set would. Later values win over
+// earlier ones at a repeated index. It panics unless there is one value
+// per index.
+func (v *Vector) SetMany(indices []int, values []float64) {
+	v.scatter(indices, values, false)
+}
+
+// ScatterAdd adds values[i] to the indices[i]'th dimension for each i,
+// accumulating the values at a repeated index. It is otherwise as
+// SetMany.
+func (v *Vector) ScatterAdd(indices []int, values []float64) {
+	v.scatter(indices, values, true)
+}
+
+// scatter implements SetMany and ScatterAdd. A Vector without options
+// has no bounds, NaN policy or observer to apply, so it is written
+// directly rather than through set.
+func (v *Vector) scatter(indices []int, values []float64, add bool) {
+	if len(indices) != len(values) {
+		panic(fmt.Errorf("%w: %d indices but %d values", ErrDimensionMismatch, len(indices), len(values)))
+	}
+
+	if v.opts != nil {
+		for i, n := range indices {
+			d := values[i]
+			if add {
+				d += v.data[n]
+			}
+
+			v.Set(n, d)
+		}
+
+		return
+	}
+
+	v.lazyInit()
+	v.invalidate()
+	for i, n := range indices {
+		if add {
+			v.data[n] += values[i]
+		} else {
+			v.data[n] = values[i]
+		}
+	}
+}
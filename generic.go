@@ -0,0 +1,172 @@
+package sparse
+
+import (
+	"iter"
+	"math"
+)
+
+// Real is the set of real element types a VectorOf may hold.
+type Real interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Number is the set of element types a VectorOf may hold.
+type Number interface {
+	Real | ~complex64 | ~complex128
+}
+
+// VectorOf is a sparse vector of any Number type, for callers that need
+// narrower storage than Vector's float64, such as float32 embeddings or
+// integer counts. It has the same mutation and aliasing semantics as
+// Vector. The zero value is an empty, zero-dimensional VectorOf ready to
+// use.
+//
+// Vector predates VectorOf and keeps its own name, options and wider API;
+// convert between them with ToVector and FromVector.
+type VectorOf[T Number] struct {
+	dim  int
+	data map[int]T
+}
+
+// NewVectorOf constructs a blank VectorOf with dim number of dimensions.
+func NewVectorOf[T Number](dim int) VectorOf[T] {
+	return VectorOf[T]{dim: dim, data: map[int]T{}}
+}
+
+// NewVectorOfFromArray maps an array to a VectorOf.
+func NewVectorOfFromArray[T Number](arr []T) VectorOf[T] {
+	ret := NewVectorOf[T](len(arr))
+	for n, d := range arr {
+		if d != 0 {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
+
+// Size is the dimensionality of the vector.
+func (v VectorOf[T]) Size() int {
+	return v.dim
+}
+
+// Set data on the n'th dimension. Setting zero removes the entry.
+func (v *VectorOf[T]) Set(n int, data T) {
+	if data == 0 {
+		delete(v.data, n)
+		return
+	}
+
+	if v.data == nil {
+		v.data = map[int]T{}
+	}
+
+	v.data[n] = data
+}
+
+// Get data from the n'th dimension.
+func (v VectorOf[T]) Get(n int) T {
+	return v.data[n]
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry, in no particular order.
+func (v VectorOf[T]) NonZeros() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for n, d := range v.data {
+			if d != 0 && !yield(n, d) {
+				return
+			}
+		}
+	}
+}
+
+// Clone a VectorOf to a new instance with its own entries.
+func (v VectorOf[T]) Clone() VectorOf[T] {
+	clone := NewVectorOf[T](v.dim)
+	for n, d := range v.data {
+		clone.data[n] = d
+	}
+
+	return clone
+}
+
+// Times a scalar, returning a new VectorOf.
+func (v VectorOf[T]) Times(scalar T) VectorOf[T] {
+	ret := NewVectorOf[T](v.dim)
+	for n, d := range v.data {
+		if d *= scalar; d != 0 {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
+
+// AddOf adds two VectorOfs. The result takes the larger dimensionality of
+// the two.
+func AddOf[T Number](v1 VectorOf[T], v2 VectorOf[T]) VectorOf[T] {
+	ret := v1.Clone()
+	ret.dim = max(v1.dim, v2.dim)
+	for n, d := range v2.data {
+		if sum := ret.data[n] + d; sum != 0 {
+			ret.data[n] = sum
+		} else {
+			delete(ret.data, n)
+		}
+	}
+
+	return ret
+}
+
+// DotOf is the sum of the element-wise products of two VectorOfs. For
+// complex types, neither operand is conjugated.
+func DotOf[T Number](v1 VectorOf[T], v2 VectorOf[T]) T {
+	if len(v2.data) < len(v1.data) {
+		v1, v2 = v2, v1
+	}
+
+	var ret T
+	for n, d1 := range v1.data {
+		ret += d1 * v2.data[n]
+	}
+
+	return ret
+}
+
+// MagnitudeOf is the Euclidean norm of a real VectorOf, computed in
+// float64.
+func MagnitudeOf[T Real](v VectorOf[T]) float64 {
+	ret := float64(0)
+	for _, d := range v.data {
+		ret += float64(d) * float64(d)
+	}
+
+	return math.Sqrt(ret)
+}
+
+// ToVector converts a real VectorOf to a Vector.
+func ToVector[T Real](v VectorOf[T]) Vector {
+	ret := NewVector(v.dim, WithCapacity(len(v.data)))
+	for n, d := range v.data {
+		ret.data[n] = float64(d)
+	}
+
+	return ret
+}
+
+// FromVector converts a Vector to a VectorOf, converting each value to T
+// as Go's conversions do, so fractions are truncated for integer types.
+// Entries that convert to zero are dropped.
+func FromVector[T Real](v Vector) VectorOf[T] {
+	ret := NewVectorOf[T](v.dim)
+	for n, d := range v.data {
+		if t := T(d); t != 0 {
+			ret.data[n] = t
+		}
+	}
+
+	return ret
+}
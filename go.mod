@@ -0,0 +1,3 @@
+module github.com/angadn/sparse
+
+go 1.24
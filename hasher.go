@@ -0,0 +1,71 @@
+package sparse
+
+// Hasher maps string features to a fixed number of dimensions with the
+// hashing trick, needing no vocabulary. Each feature's index and sign
+// are taken from its 64-bit FNV-1a hash; the sign makes colliding
+// features tend to cancel rather than accumulate, so inner products
+// between hashed Vectors are unbiased.
+type Hasher struct {
+	dim int
+}
+
+// NewHasher constructs a Hasher onto dim dimensions. It panics if dim
+// is not positive.
+func NewHasher(dim int) Hasher {
+	if dim <= 0 {
+		panic("sparse: hasher needs positive dimensions")
+	}
+
+	return Hasher{dim: dim}
+}
+
+// Size is the number of dimensions the Hasher maps onto.
+func (h Hasher) Size() int {
+	return h.dim
+}
+
+// Index returns the dimension feature hashes to and the sign, 1 or -1,
+// its values are multiplied by.
+func (h Hasher) Index(feature string) (int, float64) {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+
+	x := uint64(offset)
+	for i := 0; i < len(feature); i++ {
+		x ^= uint64(feature[i])
+		x *= prime
+	}
+
+	sign := float64(1)
+	if x>>63 == 1 {
+		sign = -1
+	}
+
+	return int((x &^ (1 << 63)) % uint64(h.dim)), sign
+}
+
+// Transform hashes features to a Vector, summing the signed values of
+// features that collide.
+func (h Hasher) Transform(features map[string]float64) Vector {
+	ret := NewVector(h.dim, WithCapacity(len(features)))
+	for f, d := range features {
+		n, sign := h.Index(f)
+		ret.data[n] += sign * d
+	}
+
+	return ret.Compact()
+}
+
+// TransformTokens hashes a bag of tokens to a Vector, counting each
+// occurrence as a value of 1.
+func (h Hasher) TransformTokens(tokens []string) Vector {
+	ret := NewVector(h.dim, WithCapacity(len(tokens)))
+	for _, t := range tokens {
+		n, sign := h.Index(t)
+		ret.data[n] += sign
+	}
+
+	return ret.Compact()
+}
@@ -0,0 +1,175 @@
+package sparse
+
+import (
+	"fmt"
+	"iter"
+)
+
+// HybridVector is a mutable vector that switches between sparse and
+// dense storage as its density changes. It densifies when more than
+// the high threshold of its dimensions are non-zero, and sparsifies
+// again below the low threshold; the gap between them keeps it from
+// switching back and forth. Its operations behave the same in either
+// representation.
+type HybridVector struct {
+	dim    int
+	sparse map[int]float64
+	dense  []float64
+	nnz    int
+	low    float64
+	high   float64
+}
+
+// NewHybridVector constructs a blank, sparse HybridVector with dim
+// number of dimensions that densifies above high density and sparsifies
+// below low. It panics unless 0 <= low <= high <= 1.
+func NewHybridVector(dim int, low float64, high float64) *HybridVector {
+	if !(0 <= low && low <= high && high <= 1) {
+		panic("sparse: hybrid thresholds must satisfy 0 <= low <= high <= 1")
+	}
+
+	return &HybridVector{dim: dim, sparse: map[int]float64{}, low: low, high: high}
+}
+
+// Size is the dimensionality of the vector.
+func (h *HybridVector) Size() int {
+	return h.dim
+}
+
+// NNZ is the number of non-zero entries.
+func (h *HybridVector) NNZ() int {
+	return h.nnz
+}
+
+// IsDense reports whether the HybridVector is currently stored densely.
+func (h *HybridVector) IsDense() bool {
+	return h.dense != nil
+}
+
+// Get data from the n'th dimension.
+func (h *HybridVector) Get(n int) float64 {
+	if h.dense != nil {
+		if n < 0 || n >= h.dim {
+			return 0
+		}
+
+		return h.dense[n]
+	}
+
+	return h.sparse[n]
+}
+
+// Set data on the n'th dimension. Setting zero removes the entry. It
+// returns ErrOutOfRange if n falls outside the vector's dimensions.
+func (h *HybridVector) Set(n int, data float64) error {
+	if n < 0 || n >= h.dim {
+		return fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, h.dim)
+	}
+
+	old := h.Get(n)
+	switch {
+	case old == 0 && data != 0:
+		h.nnz++
+	case old != 0 && data == 0:
+		h.nnz--
+	}
+
+	if h.dense != nil {
+		h.dense[n] = data
+	} else if data == 0 {
+		delete(h.sparse, n)
+	} else {
+		h.sparse[n] = data
+	}
+
+	h.rebalance()
+	return nil
+}
+
+// rebalance switches representation if the density has crossed a
+// threshold.
+func (h *HybridVector) rebalance() {
+	if h.dim == 0 {
+		return
+	}
+
+	density := float64(h.nnz) / float64(h.dim)
+	if h.dense == nil && density > h.high {
+		h.dense = make([]float64, h.dim)
+		for n, d := range h.sparse {
+			h.dense[n] = d
+		}
+
+		h.sparse = nil
+	} else if h.dense != nil && density < h.low {
+		h.sparse = make(map[int]float64, h.nnz)
+		for n, d := range h.dense {
+			if d != 0 {
+				h.sparse[n] = d
+			}
+		}
+
+		h.dense = nil
+	}
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry. Dense storage yields them in ascending index order.
+func (h *HybridVector) NonZeros() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		if h.dense != nil {
+			for n, d := range h.dense {
+				if d != 0 && !yield(n, d) {
+					return
+				}
+			}
+
+			return
+		}
+
+		for n, d := range h.sparse {
+			if !yield(n, d) {
+				return
+			}
+		}
+	}
+}
+
+// Dot product with a Vector, iterating whichever side stores fewer
+// entries.
+func (h *HybridVector) Dot(v Vector) float64 {
+	ret := float64(0)
+	if h.dense != nil || len(v.data) < h.nnz {
+		for n, d := range v.data {
+			ret += d * h.Get(n)
+		}
+
+		return ret
+	}
+
+	for n, d := range h.sparse {
+		ret += d * v.data[n]
+	}
+
+	return ret
+}
+
+// AddInPlace adds v to the HybridVector. Entries of v outside its
+// dimensions are ignored.
+func (h *HybridVector) AddInPlace(v Vector) {
+	for n, d := range v.data {
+		if n >= 0 && n < h.dim {
+			h.Set(n, h.Get(n)+d)
+		}
+	}
+}
+
+// Vector converts the HybridVector to a Vector.
+func (h *HybridVector) Vector() Vector {
+	ret := NewVector(h.dim, WithCapacity(h.nnz))
+	for n, d := range h.NonZeros() {
+		ret.data[n] = d
+	}
+
+	return ret
+}
@@ -0,0 +1,214 @@
+package sparse
+
+import "fmt"
+
+// AddInPlace adds other to the Vector, growing it to other's
+// dimensionality if that is larger. Unlike Add, it does not allocate
+// a new Vector.
+func (v *Vector) AddInPlace(other Vector) {
+	v.AddScaledInPlace(1, other)
+}
+
+// AddScaledInPlace adds alpha times other to the Vector, growing it to
+// other's dimensionality if that is larger. Each sum is written as by
+// Set.
+func (v *Vector) AddScaledInPlace(alpha float64, other Vector) {
+	v.Grow(other.dim)
+	v.axpy(alpha, other)
+}
+
+// axpy adds alpha times x to the Vector, through Set unless it has no
+// options, as scatter does.
+func (v *Vector) axpy(alpha float64, x Vector) {
+	if v.opts != nil {
+		for n, d := range x.data {
+			v.Set(n, v.data[n]+alpha*d)
+		}
+
+		return
+	}
+
+	v.lazyInit()
+	v.invalidate()
+	for n, d := range x.data {
+		v.data[n] += alpha * d
+	}
+}
+
+// AddScaled computes dst += alpha * src in a single pass over src's
+// entries, without allocating a new Vector. dst grows to src's
+// dimensionality if that is larger.
+func AddScaled(dst *Vector, src Vector, alpha float64) {
+	dst.AddScaledInPlace(alpha, src)
+}
+
+// SubInPlace subtracts other from the Vector, growing it to other's
+// dimensionality if that is larger.
+func (v *Vector) SubInPlace(other Vector) {
+	v.AddScaledInPlace(-1, other)
+}
+
+// ScaleInPlace multiplies the Vector by a scalar. Unlike Times, it does
+// not allocate a new Vector. Each product is written as by Set.
+func (v *Vector) ScaleInPlace(scalar float64) {
+	if v.opts != nil {
+		for n, d := range v.data {
+			v.Set(n, d*scalar)
+		}
+
+		return
+	}
+
+	v.invalidate()
+	for n, d := range v.data {
+		v.data[n] = d * scalar
+	}
+}
+
+// Accumulator sums many Vectors into reusable storage. The zero value is
+// an empty Accumulator ready to use.
+type Accumulator struct {
+	sum   Vector
+	count int
+}
+
+// Add v to the running sum.
+func (a *Accumulator) Add(v Vector) {
+	a.sum.AddInPlace(v)
+	a.count++
+}
+
+// AddScaled adds alpha times v to the running sum.
+func (a *Accumulator) AddScaled(alpha float64, v Vector) {
+	a.sum.AddScaledInPlace(alpha, v)
+	a.count++
+}
+
+// Count is the number of Vectors added since the last Reset.
+func (a *Accumulator) Count() int {
+	return a.count
+}
+
+// Sum returns a copy of the running sum, with the largest
+// dimensionality added.
+func (a *Accumulator) Sum() Vector {
+	return a.sum.Clone()
+}
+
+// Mean returns the mean of the Vectors added, or a zero-dimensional
+// Vector if there are none.
+func (a *Accumulator) Mean() Vector {
+	if a.count == 0 {
+		return Vector{}
+	}
+
+	return a.sum.Times(1 / float64(a.count))
+}
+
+// Reset empties the Accumulator, keeping its storage for reuse.
+func (a *Accumulator) Reset() {
+	clear(a.sum.data)
+	a.sum.dim = 0
+	a.count = 0
+}
+
+// Mean returns the centroid of vs, with the largest dimensionality
+// among them, or a zero-dimensional Vector if vs is empty. It sums vs
+// in a single pass into one Vector rather than cloning at each step as
+// repeated Adds would.
+func Mean(vs []Vector) Vector {
+	if len(vs) == 0 {
+		return Vector{}
+	}
+
+	var ret Vector
+	for _, v := range vs {
+		ret.AddInPlace(v)
+	}
+
+	ret.ScaleInPlace(1 / float64(len(vs)))
+	return ret
+}
+
+// WeightedMean returns the mean of vs weighted by w, with the largest
+// dimensionality among them, or a zero-dimensional Vector if vs is
+// empty or the weights sum to zero. It panics unless there is one
+// weight per Vector.
+func WeightedMean(vs []Vector, w []float64) Vector {
+	if len(vs) != len(w) {
+		panic(fmt.Errorf("%w: %d Vectors but %d weights", ErrDimensionMismatch, len(vs), len(w)))
+	}
+
+	var (
+		ret   Vector
+		total float64
+	)
+
+	for i, v := range vs {
+		ret.AddScaledInPlace(w[i], v)
+		total += w[i]
+	}
+
+	if total == 0 {
+		return Vector{}
+	}
+
+	ret.ScaleInPlace(1 / total)
+	return ret
+}
+
+// ReduceSum returns the element-wise sum of vs, with the largest
+// dimensionality among them, accumulated in a single pass into one
+// Vector. Entries that cancel out are left out.
+func ReduceSum(vs []Vector) Vector {
+	return reduce(vs, func(a float64, b float64) float64 { return a + b })
+}
+
+// ReduceMax returns the element-wise maximum of vs, as when max-pooling
+// token Vectors into one, with the largest dimensionality among them.
+// A dimension a Vector does not store counts as zero, so negative
+// values survive only where every Vector stores them.
+func ReduceMax(vs []Vector) Vector {
+	return reduce(vs, func(a float64, b float64) float64 { return max(a, b) })
+}
+
+// ReduceMin returns the element-wise minimum of vs, treating unstored
+// dimensions as zero as ReduceMax does.
+func ReduceMin(vs []Vector) Vector {
+	return reduce(vs, func(a float64, b float64) float64 { return min(a, b) })
+}
+
+// reduce folds the values of vs at each stored index with fn, counting
+// the Vectors storing it so that the implicit zeros of the others can
+// be folded in once at the end.
+func reduce(vs []Vector, fn func(a float64, b float64) float64) Vector {
+	var ret Vector
+	counts := map[int]int{}
+	for _, v := range vs {
+		ret.Grow(v.dim)
+		ret.lazyInit()
+		for n, d := range v.data {
+			if acc, ok := ret.data[n]; ok {
+				ret.data[n] = fn(acc, d)
+			} else {
+				ret.data[n] = d
+			}
+
+			counts[n]++
+		}
+	}
+
+	for n, d := range ret.data {
+		if counts[n] < len(vs) {
+			d = fn(d, 0)
+		}
+
+		if d == 0 {
+			delete(ret.data, n)
+		} else {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
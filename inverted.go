@@ -0,0 +1,130 @@
+package sparse
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"math"
+)
+
+// Scoring is how an InvertedIndex scores documents against a query.
+type Scoring int
+
+const (
+	// DotProduct scores a document by its Dot product with the query.
+	DotProduct Scoring = iota
+
+	// BM25 scores a document by Okapi BM25, treating its values as term
+	// frequencies and the query's as term weights. Terms are weighted
+	// by their inverse document frequency, and frequencies saturate and
+	// are normalized by the document's length, the sum of its values.
+	BM25
+)
+
+const (
+	// bm25K1 is how quickly BM25 saturates term frequencies.
+	bm25K1 = 1.2
+
+	// bm25B is how strongly BM25 normalizes by document length.
+	bm25B = 0.75
+)
+
+// posting is a document and its value on one dimension.
+type posting struct {
+	doc   int
+	value float64
+}
+
+// InvertedIndex indexes Vectors by their non-zero dimensions for sparse
+// retrieval. A query only visits the posting lists of the dimensions it
+// stores, rather than every indexed Vector, so for text-like Vectors it
+// scores far fewer documents than a scan with Dot.
+type InvertedIndex struct {
+	scoring  Scoring
+	ids      []string
+	lengths  []float64
+	total    float64
+	postings map[int][]posting
+}
+
+// NewInvertedIndex constructs an empty InvertedIndex that scores queries
+// by scoring. It panics on an unknown Scoring.
+func NewInvertedIndex(scoring Scoring) *InvertedIndex {
+	if scoring != DotProduct && scoring != BM25 {
+		panic(fmt.Sprintf("sparse: unknown scoring %d", scoring))
+	}
+
+	return &InvertedIndex{scoring: scoring, postings: map[int][]posting{}}
+}
+
+// Add v to the InvertedIndex under id.
+func (idx *InvertedIndex) Add(id string, v Vector) {
+	doc := len(idx.ids)
+	length := float64(0)
+	for n, d := range v.NonZeros() {
+		idx.postings[n] = append(idx.postings[n], posting{doc: doc, value: d})
+		length += d
+	}
+
+	idx.ids = append(idx.ids, id)
+	idx.lengths = append(idx.lengths, length)
+	idx.total += length
+}
+
+// AddAll adds each of vs to the InvertedIndex under the id at the same
+// position, as the traced operation "InvertedIndex.AddAll" (see
+// SetTracer). If ctx is done part way through, it stops and returns
+// ctx's error, leaving the Vectors added so far in the index.
+func (idx *InvertedIndex) AddAll(ctx context.Context, ids []string, vs []Vector) error {
+	return addAll(ctx, "InvertedIndex.AddAll", ids, vs, idx.Add)
+}
+
+// Len is the number of Vectors in the InvertedIndex.
+func (idx *InvertedIndex) Len() int {
+	return len(idx.ids)
+}
+
+// Score returns the topK documents scoring highest against query, best
+// first. Only documents sharing a non-zero dimension with query are
+// scored, so fewer may be returned.
+func (idx *InvertedIndex) Score(query Vector, topK int) []Match {
+	if topK <= 0 {
+		return nil
+	}
+
+	scores := map[int]float64{}
+	avg := idx.total / float64(len(idx.ids))
+	for n, q := range query.NonZeros() {
+		list := idx.postings[n]
+		if len(list) == 0 {
+			continue
+		}
+
+		idf := math.Log(1 + (float64(len(idx.ids)-len(list))+0.5)/(float64(len(list))+0.5))
+		for _, p := range list {
+			if idx.scoring == BM25 {
+				norm := bm25K1 * (1 - bm25B + bm25B*idx.lengths[p.doc]/avg)
+				scores[p.doc] += q * idf * p.value * (bm25K1 + 1) / (p.value + norm)
+			} else {
+				scores[p.doc] += q * p.value
+			}
+		}
+	}
+
+	h := make(matchHeap, 0, min(topK, len(scores)))
+	for doc, score := range scores {
+		if len(h) < topK {
+			heap.Push(&h, Match{ID: idx.ids[doc], Score: score})
+		} else if score > h[0].Score {
+			h[0] = Match{ID: idx.ids[doc], Score: score}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	ret := make([]Match, len(h))
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(&h).(Match)
+	}
+
+	return ret
+}
@@ -0,0 +1,36 @@
+package sparse
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Iterate calls fn with the index and value of each non-zero entry of
+// the Vector, in no particular order, until fn returns false.
+func (v Vector) Iterate(fn func(index int, value float64) bool) {
+	for n, d := range v.data {
+		if d != 0 && !fn(n, d) {
+			return
+		}
+	}
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry of the Vector, in no particular order.
+func (v Vector) NonZeros() iter.Seq2[int, float64] {
+	return v.Iterate
+}
+
+// SortedNonZeros returns an iterator over the index and value of each
+// non-zero entry of the Vector, in ascending index order. It sorts the
+// indices up front, so it costs O(nnz log nnz) before the first entry.
+func (v Vector) SortedNonZeros() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for _, n := range slices.Sorted(maps.Keys(v.data)) {
+			if d := v.data[n]; d != 0 && !yield(n, d) {
+				return
+			}
+		}
+	}
+}
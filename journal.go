@@ -0,0 +1,106 @@
+package sparse
+
+// Journal wraps a Vector, recording each Set and Remove so they can be
+// undone back to a Checkpoint without copying the Vector.
+type Journal struct {
+	v           *Vector
+	ops         []journalOp
+	checkpoints []int
+}
+
+// journalOp records the state of an index before it was written.
+type journalOp struct {
+	n      int
+	dim    int
+	data   float64
+	stored bool
+}
+
+// NewJournal wraps v, which should only be mutated through the Journal
+// while it is in use.
+func NewJournal(v *Vector) *Journal {
+	return &Journal{v: v}
+}
+
+// Vector returns the wrapped Vector.
+func (j *Journal) Vector() *Vector {
+	return j.v
+}
+
+// record the current state of the n'th dimension.
+func (j *Journal) record(n int) {
+	d, ok := j.v.GetOK(n)
+	j.ops = append(j.ops, journalOp{n: n, dim: j.v.dim, data: d, stored: ok})
+}
+
+// Set data on the n'th dimension, as Vector.Set.
+func (j *Journal) Set(n int, data float64) {
+	j.record(n)
+	j.v.Set(n, data)
+}
+
+// SetChecked sets data on the n'th dimension, as Vector.SetChecked. Writes
+// it rejects are not recorded.
+func (j *Journal) SetChecked(n int, data float64) error {
+	j.record(n)
+	if err := j.v.SetChecked(n, data); err != nil {
+		j.ops = j.ops[:len(j.ops)-1]
+		return err
+	}
+
+	return nil
+}
+
+// Remove the entry on the n'th dimension, as Vector.Remove.
+func (j *Journal) Remove(n int) {
+	j.record(n)
+	j.v.Remove(n)
+}
+
+// Checkpoint marks the current state for a later Rollback. Checkpoints
+// nest.
+func (j *Journal) Checkpoint() {
+	j.checkpoints = append(j.checkpoints, len(j.ops))
+}
+
+// Rollback undoes every operation since the latest Checkpoint, and
+// discards it. Without a Checkpoint, it undoes every recorded operation.
+// Entries are restored exactly, bypassing the Vector's Bounds, NaNPolicy
+// and tolerance, but its Observer is notified of each as by Set and
+// Remove.
+func (j *Journal) Rollback() {
+	mark := 0
+	if k := len(j.checkpoints); k > 0 {
+		mark = j.checkpoints[k-1]
+		j.checkpoints = j.checkpoints[:k-1]
+	}
+
+	j.v.invalidate()
+	obs := j.v.opts.observerOf()
+	for i := len(j.ops) - 1; i >= mark; i-- {
+		op := j.ops[i]
+		cur, ok := j.v.data[op.n]
+		if op.stored {
+			j.v.lazyInit()
+			j.v.data[op.n] = op.data
+			if obs != nil {
+				obs.Changed(op.n, cur, op.data)
+			}
+		} else if ok {
+			delete(j.v.data, op.n)
+			if obs != nil {
+				obs.Removed(op.n, cur)
+			}
+		}
+
+		j.v.dim = op.dim
+	}
+
+	j.ops = j.ops[:mark]
+}
+
+// Commit discards the undo history, keeping the Vector's current state.
+func (j *Journal) Commit() {
+	j.ops = j.ops[:0]
+	j.checkpoints = j.checkpoints[:0]
+}
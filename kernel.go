@@ -0,0 +1,38 @@
+package sparse
+
+import "math"
+
+// Kernel is a similarity function between two Vectors, as used by
+// kernel machines such as SVMs. The Kernels here are computed from Dot
+// and magnitudes, so each costs little more than a sparse dot product.
+type Kernel func(x Vector, y Vector) float64
+
+// Linear is the Kernel x·y.
+func Linear() Kernel {
+	return Dot
+}
+
+// RBF is the Gaussian radial basis function Kernel exp(-gamma‖x-y‖²),
+// with the squared distance expanded as ‖x‖² + ‖y‖² - 2x·y. Magnitudes
+// are served from the cache of Vectors constructed with
+// WithCachedNorms, which makes scoring against fixed support vectors
+// cheaper.
+func RBF(gamma float64) Kernel {
+	return func(x Vector, y Vector) float64 {
+		return math.Exp(-gamma * sqDist(x, y, x.CachedMagnitude(), y.CachedMagnitude()))
+	}
+}
+
+// Polynomial is the Kernel (x·y + coef)^degree.
+func Polynomial(degree int, coef float64) Kernel {
+	return func(x Vector, y Vector) float64 {
+		return math.Pow(Dot(x, y)+coef, float64(degree))
+	}
+}
+
+// Sigmoid is the Kernel tanh(alpha·x·y + c).
+func Sigmoid(alpha float64, c float64) Kernel {
+	return func(x Vector, y Vector) float64 {
+		return math.Tanh(alpha*Dot(x, y) + c)
+	}
+}
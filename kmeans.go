@@ -0,0 +1,159 @@
+package sparse
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// kmeansMaxIter bounds the number of Lloyd iterations KMeans runs.
+const kmeansMaxIter = 100
+
+// KMeans partitions vs into k clusters under the Euclidean or Cosine
+// metric, returning the cluster of each Vector and each cluster's
+// centroid. Centroids are seeded by k-means++ from seed, so the result
+// is reproducible, and refined until no assignment changes.
+//
+// Centroids are accumulated sparsely, storing only dimensions some
+// member of the cluster stores. Under Cosine the Vectors are clustered
+// by direction (spherical k-means) and centroids have unit length; zero
+// vectors are assigned to the first cluster.
+//
+// It panics unless k is in [1, len(vs)] and metric is Euclidean or
+// Cosine.
+func KMeans(vs []Vector, k int, metric Metric, seed uint64) ([]int, []Vector) {
+	assign, centroids, _ := kmeans(context.Background(), vs, k, metric, seed)
+	return assign, centroids
+}
+
+// KMeansContext runs KMeans as the traced operation "KMeans" (see
+// SetTracer). If ctx is done before the clustering converges, it
+// returns the assignments and centroids of the last completed
+// iteration, or none if there was none, along with ctx's error.
+func KMeansContext(ctx context.Context, vs []Vector, k int, metric Metric, seed uint64) ([]int, []Vector, error) {
+	var (
+		assign    []int
+		centroids []Vector
+	)
+
+	err := instrument(ctx, "KMeans", func(ctx context.Context) error {
+		var err error
+		assign, centroids, err = kmeans(ctx, vs, k, metric, seed)
+		return err
+	})
+
+	return assign, centroids, err
+}
+
+// kmeans implements KMeans and KMeansContext, checking ctx before each
+// iteration.
+func kmeans(ctx context.Context, vs []Vector, k int, metric Metric, seed uint64) ([]int, []Vector, error) {
+	if k < 1 || k > len(vs) {
+		panic(fmt.Sprintf("sparse: k-means needs 1 to %d clusters, not %d", len(vs), k))
+	} else if metric != Euclidean && metric != Cosine {
+		panic(fmt.Sprintf("sparse: k-means needs the Euclidean or Cosine metric, not %d", metric))
+	}
+
+	if metric == Cosine {
+		unit := make([]Vector, len(vs))
+		parallel(len(vs), func(i int) {
+			unit[i] = vs[i].Normalize(2)
+		})
+
+		vs = unit
+	}
+
+	norms := magnitudes(vs)
+	centroids := kmeansPlusPlus(vs, norms, k, rand.New(rand.NewPCG(seed, 0)))
+	assign := make([]int, len(vs))
+	for iter := 0; iter < kmeansMaxIter; iter++ {
+		if err := ctx.Err(); err != nil && iter == 0 {
+			return nil, nil, err
+		} else if err != nil {
+			return assign, centroids, err
+		}
+
+		cnorms := magnitudes(centroids)
+		changed := make([]bool, len(vs))
+		parallel(len(vs), func(i int) {
+			best, bestDist := 0, math.Inf(1)
+			for c, centroid := range centroids {
+				if d := sqDist(vs[i], centroid, norms[i], cnorms[c]); d < bestDist {
+					best, bestDist = c, d
+				}
+			}
+
+			changed[i] = assign[i] != best
+			assign[i] = best
+		})
+
+		if iter > 0 && !slices.Contains(changed, true) {
+			break
+		}
+
+		sums := make([]Accumulator, k)
+		for i, c := range assign {
+			sums[c].Add(vs[i])
+		}
+
+		for c := range centroids {
+			if sums[c].Count() == 0 {
+				continue // an empty cluster keeps its centroid
+			}
+
+			centroids[c] = sums[c].Mean()
+			if metric == Cosine {
+				centroids[c] = centroids[c].Normalize(2)
+			}
+		}
+	}
+
+	return assign, centroids, nil
+}
+
+// kmeansPlusPlus picks k initial centroids from vs, each chosen with
+// probability proportional to its squared distance from the nearest
+// centroid already picked.
+func kmeansPlusPlus(vs []Vector, norms []float64, k int, r *rand.Rand) []Vector {
+	first := r.IntN(len(vs))
+	centroids := []Vector{vs[first].Clone()}
+	dists := make([]float64, len(vs))
+	for i := range vs {
+		dists[i] = math.Inf(1)
+	}
+
+	last := first
+	for len(centroids) < k {
+		total := float64(0)
+		for i, v := range vs {
+			dists[i] = min(dists[i], sqDist(v, vs[last], norms[i], norms[last]))
+			total += dists[i]
+		}
+
+		// If every Vector lies on a centroid, they coincide and any
+		// will do.
+		next := len(centroids)
+		target := r.Float64() * total
+		for i, d := range dists {
+			if d > 0 {
+				next = i
+				if target -= d; target < 0 {
+					break
+				}
+			}
+		}
+
+		centroids = append(centroids, vs[next].Clone())
+		last = next
+	}
+
+	return centroids
+}
+
+// sqDist is the squared Euclidean distance between two Vectors with the
+// given magnitudes.
+func sqDist(v1 Vector, v2 Vector, norm1 float64, norm2 float64) float64 {
+	return max(norm1*norm1+norm2*norm2-2*Dot(v1, v2), 0)
+}
@@ -0,0 +1,174 @@
+package sparse
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Dictionary assigns indices to string labels, in order of first use. It
+// is safe for concurrent use, so it can be shared between LabeledVectors.
+type Dictionary struct {
+	mu     sync.RWMutex
+	index  map[string]int
+	labels []string
+}
+
+// NewDictionary constructs a Dictionary, assigning indices to labels in
+// the order given.
+func NewDictionary(labels ...string) *Dictionary {
+	d := &Dictionary{index: make(map[string]int, len(labels))}
+	for _, label := range labels {
+		d.Add(label)
+	}
+
+	return d
+}
+
+// Add returns the index of label, assigning it the next one if it is
+// new.
+func (d *Dictionary) Add(label string) int {
+	if n, ok := d.Index(label); ok {
+		return n
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if n, ok := d.index[label]; ok {
+		return n
+	}
+
+	if d.index == nil {
+		d.index = map[string]int{}
+	}
+
+	n := len(d.labels)
+	d.index[label] = n
+	d.labels = append(d.labels, label)
+	return n
+}
+
+// Index returns the index of label, and whether it has one.
+func (d *Dictionary) Index(label string) (int, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	n, ok := d.index[label]
+	return n, ok
+}
+
+// Label returns the label of the n'th index, and whether it has one.
+func (d *Dictionary) Label(n int) (string, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if n < 0 || n >= len(d.labels) {
+		return "", false
+	}
+
+	return d.labels[n], true
+}
+
+// Len is the number of labels in the Dictionary.
+func (d *Dictionary) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.labels)
+}
+
+// LabeledVector is a Vector addressed by string labels, which a
+// Dictionary maps to its indices. The zero value uses a Dictionary of
+// its own.
+type LabeledVector struct {
+	dict *Dictionary
+	v    Vector
+}
+
+// NewLabeledVector constructs a blank LabeledVector over dict.
+func NewLabeledVector(dict *Dictionary) LabeledVector {
+	return LabeledVector{dict: dict}
+}
+
+// Dictionary returns the Dictionary the LabeledVector is built against.
+func (lv *LabeledVector) Dictionary() *Dictionary {
+	if lv.dict == nil {
+		lv.dict = NewDictionary()
+	}
+
+	return lv.dict
+}
+
+// Set data on the dimension labeled label, adding it to the Dictionary
+// if it is new.
+func (lv *LabeledVector) Set(label string, data float64) {
+	lv.v.Set(lv.Dictionary().Add(label), data)
+}
+
+// Get data from the dimension labeled label.
+func (lv LabeledVector) Get(label string) float64 {
+	if lv.dict == nil {
+		return 0
+	}
+
+	n, ok := lv.dict.Index(label)
+	if !ok {
+		return 0
+	}
+
+	return lv.v.data[n]
+}
+
+// Remove the entry on the dimension labeled label.
+func (lv *LabeledVector) Remove(label string) {
+	if n, ok := lv.Dictionary().Index(label); ok {
+		lv.v.Remove(n)
+	}
+}
+
+// Vector returns a copy of the LabeledVector's entries as a Vector, with
+// one dimension per label in its Dictionary.
+func (lv LabeledVector) Vector() Vector {
+	ret := lv.v.Clone()
+	ret.dim = lv.Dictionary().Len()
+	return ret
+}
+
+// Align re-expresses the LabeledVector over dict, adding any of its
+// labels dict lacks, so it can be combined with Vectors built against
+// dict.
+func (lv LabeledVector) Align(dict *Dictionary) LabeledVector {
+	ret := NewLabeledVector(dict)
+	for n, d := range lv.v.data {
+		if label, ok := lv.Dictionary().Label(n); ok {
+			ret.Set(label, d)
+		}
+	}
+
+	return ret
+}
+
+// MarshalJSON encodes the LabeledVector as an object from labels to
+// values.
+func (lv LabeledVector) MarshalJSON() ([]byte, error) {
+	entries := make(map[string]float64, len(lv.v.data))
+	for n, d := range lv.v.data {
+		if label, ok := lv.Dictionary().Label(n); ok {
+			entries[label] = d
+		}
+	}
+
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON decodes an object from labels to values into the
+// LabeledVector, adding labels to its Dictionary as needed.
+func (lv *LabeledVector) UnmarshalJSON(b []byte) error {
+	var entries map[string]float64
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return err
+	}
+
+	lv.v = Vector{}
+	for label, d := range entries {
+		lv.Set(label, d)
+	}
+
+	return nil
+}
@@ -0,0 +1,21 @@
+package sparse
+
+import "testing"
+
+func TestLabeledVector(t *testing.T) {
+	var lv LabeledVector
+	lv.Set("a", 1)
+	lv.Set("b", 2)
+	lv.Set("a", 3)
+	lv.Remove("b")
+	lv.Remove("missing")
+
+	if lv.Get("a") != 3 || lv.Get("b") != 0 {
+		t.Fatalf("Get(a), Get(b) = %g, %g", lv.Get("a"), lv.Get("b"))
+	}
+
+	v := lv.Vector()
+	if v.Size() != 2 || v.NNZ() != 1 || v.Magnitude() != 3 {
+		t.Fatalf("Vector() = %v", v)
+	}
+}
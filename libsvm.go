@@ -0,0 +1,93 @@
+package sparse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadLibSVM reads the libsvm (SVMlight) "label index:value ..." format,
+// returning each line's label and features. Indices are 1-based, every
+// Vector has as many dimensions as the largest index in the file, and
+// SVMlight's qid fields and trailing # comments are skipped.
+func ReadLibSVM(r io.Reader) ([]float64, []Vector, error) {
+	var (
+		labels  []float64
+		rows    []Vector
+		dim     int
+		scanner = newLineScanner(r)
+	)
+
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		label, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, nil, fmt.Errorf("sparse: libsvm: line %d: %w", line, err)
+		}
+
+		row := NewVector(0, WithCapacity(len(fields)-1))
+		for _, f := range fields[1:] {
+			idx, val, ok := strings.Cut(f, ":")
+			if idx == "qid" {
+				continue
+			}
+
+			n, err1 := strconv.Atoi(idx)
+			d, err2 := strconv.ParseFloat(val, 64)
+			if err := errors.Join(err1, err2); !ok || err != nil || n < 1 {
+				return nil, nil, fmt.Errorf("sparse: libsvm: line %d: malformed pair %q", line, f)
+			} else if row.Has(n - 1) {
+				return nil, nil, fmt.Errorf("%w: libsvm line %d: %d", ErrDuplicateIndex, line, n)
+			}
+
+			row.Grow(n)
+			row.Set(n-1, d)
+		}
+
+		dim = max(dim, row.dim)
+		labels = append(labels, label)
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("sparse: libsvm: %w", err)
+	}
+
+	for i := range rows {
+		rows[i].Grow(dim)
+	}
+
+	return labels, rows, nil
+}
+
+// WriteLibSVM writes rows in the libsvm format with 1-based indices,
+// using a label of 0 for rows beyond the end of labels. Entries stored
+// outside a row's dimensions are left out.
+func WriteLibSVM(w io.Writer, labels []float64, rows []Vector) error {
+	bw := bufio.NewWriter(w)
+	for i, row := range rows {
+		label := float64(0)
+		if i < len(labels) {
+			label = labels[i]
+		}
+
+		bw.WriteString(strconv.FormatFloat(label, 'g', -1, 64))
+		for n, d := range row.SortedNonZeros() {
+			if n >= 0 && n < row.dim {
+				fmt.Fprintf(bw, " %d:%s", n+1, strconv.FormatFloat(d, 'g', -1, 64))
+			}
+		}
+
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,26 @@
+package sparse
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadLibSVMLongLine(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("1")
+	for n := 1; n <= 20000; n++ { // well past bufio.MaxScanTokenSize
+		b.WriteString(" " + strconv.Itoa(n) + ":0.5")
+	}
+
+	labels, rows, err := ReadLibSVM(strings.NewReader(b.String() + "\n-1 3:1\n"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(labels) != 2 || len(rows) != 2 {
+		t.Fatalf("got %d labels and %d rows, want 2", len(labels), len(rows))
+	}
+
+	if rows[0].NNZ() != 20000 || labels[1] != -1 {
+		t.Errorf("got %d entries and label %g, want 20000 and -1", rows[0].NNZ(), labels[1])
+	}
+}
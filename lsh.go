@@ -0,0 +1,148 @@
+package sparse
+
+import (
+	"container/heap"
+	"context"
+	"math"
+)
+
+// LSHIndex is an approximate nearest-neighbour index for cosine
+// similarity, using random-hyperplane SimHash. Each Vector is hashed to
+// bands signatures of rows bits each; a query's candidates are the
+// Vectors that agree with it on every bit of at least one band, which
+// are then ranked exactly.
+//
+// Two Vectors at angle θ agree on each bit with probability
+// s = 1 - θ/π, so they become candidates with probability
+// 1 - (1 - s^rows)^bands. More rows make the index more selective and
+// faster; more bands raise recall at the cost of memory and candidates.
+//
+// Hyperplanes are derived from a hash of the seed, plane and index
+// rather than stored, so the index works for any dimensionality.
+type LSHIndex struct {
+	bands, rows int
+	planes      []uint64 // per-hyperplane hash seeds
+	tables      []map[uint64][]int
+	vectors     Collection
+}
+
+// NewLSHIndex constructs an empty LSHIndex with the given number of
+// bands and rows per band, seeding its hyperplanes with seed. It panics
+// unless bands is positive and rows is in [1, 64].
+func NewLSHIndex(bands int, rows int, seed uint64) *LSHIndex {
+	if bands <= 0 || rows <= 0 || rows > 64 {
+		panic("sparse: LSH needs positive bands and 1 to 64 rows")
+	}
+
+	idx := &LSHIndex{bands: bands, rows: rows, planes: make([]uint64, bands*rows), tables: make([]map[uint64][]int, bands)}
+	for p := range idx.planes {
+		idx.planes[p] = splitmix(seed + uint64(p))
+	}
+
+	for b := range idx.tables {
+		idx.tables[b] = map[uint64][]int{}
+	}
+
+	return idx
+}
+
+// signatures computes v's band signatures.
+func (idx *LSHIndex) signatures(v Vector) []uint64 {
+	dots := make([]float64, len(idx.planes))
+	for n, d := range v.data {
+		if d == 0 {
+			continue
+		}
+
+		for p := range dots {
+			if splitmix(idx.planes[p]^uint64(n))&1 == 0 {
+				dots[p] += d
+			} else {
+				dots[p] -= d
+			}
+		}
+	}
+
+	ret := make([]uint64, idx.bands)
+	for p, dot := range dots {
+		if dot > 0 {
+			ret[p/idx.rows] |= 1 << (p % idx.rows)
+		}
+	}
+
+	return ret
+}
+
+// splitmix is the SplitMix64 finalizer, a fast well-mixed hash.
+func splitmix(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}
+
+// Add v to the index under id. The index keeps its own copy.
+func (idx *LSHIndex) Add(id string, v Vector) {
+	i := idx.vectors.Len()
+	idx.vectors.Add(id, v)
+	for b, sig := range idx.signatures(v) {
+		idx.tables[b][sig] = append(idx.tables[b][sig], i)
+	}
+}
+
+// AddAll adds each of vs to the index under the id at the same
+// position, as the traced operation "LSHIndex.AddAll" (see SetTracer).
+// If ctx is done part way through, it stops and returns ctx's error,
+// leaving the Vectors added so far in the index.
+func (idx *LSHIndex) AddAll(ctx context.Context, ids []string, vs []Vector) error {
+	return addAll(ctx, "LSHIndex.AddAll", ids, vs, idx.Add)
+}
+
+// Len is the number of Vectors in the index.
+func (idx *LSHIndex) Len() int {
+	return idx.vectors.Len()
+}
+
+// NearestK returns approximately the k Vectors in the index most
+// similar to query, nearest first by cosine distance. Only candidates
+// sharing a band signature with query are considered, so fewer than k
+// may be returned. It returns nil unless k is positive.
+func (idx *LSHIndex) NearestK(query Vector, k int) []Neighbor {
+	if k <= 0 {
+		return nil
+	}
+
+	seen := map[int]bool{}
+	var candidates []int
+	for b, sig := range idx.signatures(query) {
+		for _, i := range idx.tables[b][sig] {
+			if !seen[i] {
+				seen[i] = true
+				candidates = append(candidates, i)
+			}
+		}
+	}
+
+	qnorm := query.CachedMagnitude()
+	h := make(neighborHeap, 0, min(k, len(candidates)))
+	for _, i := range candidates {
+		d := 1 - Dot(query, idx.vectors.vecs[i])/(qnorm*idx.vectors.norms[i])
+		if math.IsNaN(d) {
+			continue
+		}
+
+		if len(h) < k {
+			heap.Push(&h, Neighbor{ID: idx.vectors.ids[i], Distance: d})
+		} else if d < h[0].Distance {
+			h[0] = Neighbor{ID: idx.vectors.ids[i], Distance: d}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	ret := make([]Neighbor, len(h))
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(&h).(Neighbor)
+	}
+
+	return ret
+}
@@ -0,0 +1,22 @@
+package sparse
+
+import "testing"
+
+func TestLSHNearestK(t *testing.T) {
+	idx := NewLSHIndex(8, 4, 1)
+	idx.Add("x", NewVectorFromArray([]float64{1, 0, 0}))
+	idx.Add("near", NewVectorFromArray([]float64{1, 0.05, 0}))
+	idx.Add("zero", NewVector(3))
+
+	query := NewVectorFromArray([]float64{1, 0.01, 0})
+	for _, k := range []int{-1, 0} {
+		if got := idx.NearestK(query, k); got != nil {
+			t.Errorf("NearestK(k=%d) = %v, want nil", k, got)
+		}
+	}
+
+	got := idx.NearestK(query, 1<<40)
+	if len(got) != 2 || got[0].ID != "x" || got[1].ID != "near" {
+		t.Fatalf("NearestK(k=1<<40) = %v, want x then near", got)
+	}
+}
@@ -0,0 +1,98 @@
+package sparse
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Mul multiplies the Matrix by b, returning a CSR Matrix with the rows
+// of this one and the columns of b. It uses Gustavson's row-merge
+// algorithm, accumulating each row of the product in a dense workspace
+// reused across rows, so it costs time proportional to the number of
+// scalar products plus the product's entries.
+//
+// Mul does not check that the Matrix's columns match b's rows, treating
+// missing rows of b as zero; see MulE for a checked variant.
+func (m Matrix) Mul(b Matrix) Matrix {
+	a, b := m.ToCSR(), b.ToCSR()
+	ret := Matrix{rows: a.rows, cols: b.cols, ptr: make([]int, 1, a.rows+1)}
+	acc := make([]float64, b.cols)
+	seen := make([]bool, b.cols)
+	var touched []int
+	for i := range a.rows {
+		ind, val := a.major(i)
+		for p, k := range ind {
+			if k >= b.rows {
+				continue
+			}
+
+			bind, bval := b.major(k)
+			for q, j := range bind {
+				if !seen[j] {
+					seen[j] = true
+					touched = append(touched, j)
+				}
+
+				acc[j] += val[p] * bval[q]
+			}
+		}
+
+		slices.Sort(touched)
+		for _, j := range touched {
+			if acc[j] != 0 {
+				ret.ind = append(ret.ind, j)
+				ret.val = append(ret.val, acc[j])
+			}
+
+			acc[j], seen[j] = 0, false
+		}
+
+		touched = touched[:0]
+		ret.ptr = append(ret.ptr, len(ret.ind))
+	}
+
+	return ret
+}
+
+// MulE multiplies the Matrix by b like Mul, returning
+// ErrDimensionMismatch unless the Matrix's columns match b's rows.
+func (m Matrix) MulE(b Matrix) (Matrix, error) {
+	if m.cols != b.rows {
+		return Matrix{}, fmt.Errorf("%w: %d columns != %d rows", ErrDimensionMismatch, m.cols, b.rows)
+	}
+
+	return m.Mul(b), nil
+}
+
+// MulContext multiplies the Matrix by b like MulE, as the traced
+// operation "Matrix.Mul" (see SetTracer). It returns ctx's error
+// without multiplying if ctx is already done.
+func (m Matrix) MulContext(ctx context.Context, b Matrix) (Matrix, error) {
+	var ret Matrix
+	err := instrument(ctx, "Matrix.Mul", func(ctx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var err error
+		ret, err = m.MulE(b)
+		return err
+	})
+
+	return ret, err
+}
+
+// MulT multiplies the transpose of the Matrix by b, computing mᵀb
+// without materializing the transpose beyond one conversion between
+// CSR and CSC. Like Mul, it treats missing rows of b as zero.
+func (m Matrix) MulT(b Matrix) Matrix {
+	return m.T().Mul(b)
+}
+
+// Gram returns the Gram matrix mᵀm of the Matrix, whose entry (i, j) is
+// the dot product of its i'th and j'th columns. For a document-term
+// Matrix of counts, it is the term co-occurrence matrix.
+func (m Matrix) Gram() Matrix {
+	return m.MulT(m)
+}
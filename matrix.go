@@ -0,0 +1,332 @@
+package sparse
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Storage is the compressed layout of a Matrix.
+type Storage int
+
+const (
+	// CSR (compressed sparse row) stores a Matrix row by row, making
+	// row access and MulVec fast.
+	CSR Storage = iota
+
+	// CSC (compressed sparse column) stores a Matrix column by column,
+	// making column access fast.
+	CSC
+)
+
+// Matrix is an immutable sparse matrix in CSR or CSC storage. The zero
+// value is an empty 0×0 Matrix.
+type Matrix struct {
+	rows, cols int
+	storage    Storage
+
+	// ptr[k]:ptr[k+1] spans the indices and values of the k'th row in
+	// CSR storage, or column in CSC storage.
+	ptr []int
+	ind []int
+	val []float64
+}
+
+// NewMatrixFromRows builds a CSR Matrix from one Vector per row, with as
+// many columns as the largest row's dimensionality. Entries stored
+// outside a row's dimensions are left out.
+func NewMatrixFromRows(rows []Vector) Matrix {
+	ret := Matrix{rows: len(rows), ptr: make([]int, 1, len(rows)+1)}
+	for _, row := range rows {
+		ret.cols = max(ret.cols, row.dim)
+		for _, n := range slices.Sorted(maps.Keys(row.data)) {
+			if n >= 0 && n < row.dim {
+				ret.ind = append(ret.ind, n)
+				ret.val = append(ret.val, row.data[n])
+			}
+		}
+
+		ret.ptr = append(ret.ptr, len(ret.ind))
+	}
+
+	return ret
+}
+
+// NewMatrixFromTriplets builds a rows×cols Matrix in the given storage
+// from parallel slices of row indices, column indices and values, as in
+// COO sources. It returns ErrOutOfRange if an entry falls outside the
+// Matrix, and sums the values of repeated entries.
+func NewMatrixFromTriplets(rows int, cols int, is []int, js []int, values []float64, storage Storage) (Matrix, error) {
+	if len(is) != len(js) || len(is) != len(values) {
+		return Matrix{}, fmt.Errorf("%w: %d rows, %d columns and %d values", ErrDimensionMismatch, len(is), len(js), len(values))
+	}
+
+	major, minor, n := is, js, rows
+	if storage == CSC {
+		major, minor, n = js, is, cols
+	}
+
+	order := make([]int, len(values))
+	for k := range order {
+		if is[k] < 0 || is[k] >= rows || js[k] < 0 || js[k] >= cols {
+			return Matrix{}, fmt.Errorf("%w: (%d, %d) not in %d×%d", ErrOutOfRange, is[k], js[k], rows, cols)
+		}
+
+		order[k] = k
+	}
+
+	slices.SortFunc(order, func(a, b int) int {
+		if major[a] != major[b] {
+			return major[a] - major[b]
+		}
+
+		return minor[a] - minor[b]
+	})
+
+	ret := Matrix{rows: rows, cols: cols, storage: storage, ptr: make([]int, n+1)}
+	for i, k := range order {
+		if i > 0 && major[k] == major[order[i-1]] && minor[k] == minor[order[i-1]] {
+			ret.val[len(ret.val)-1] += values[k]
+			continue
+		}
+
+		ret.ptr[major[k]+1]++
+		ret.ind = append(ret.ind, minor[k])
+		ret.val = append(ret.val, values[k])
+	}
+
+	for k := range n {
+		ret.ptr[k+1] += ret.ptr[k]
+	}
+
+	return ret, nil
+}
+
+// Dims returns the number of rows and columns of the Matrix.
+func (m Matrix) Dims() (int, int) {
+	return m.rows, m.cols
+}
+
+// Storage returns the compressed layout of the Matrix.
+func (m Matrix) Storage() Storage {
+	return m.storage
+}
+
+// NNZ is the number of entries stored in the Matrix.
+func (m Matrix) NNZ() int {
+	return len(m.val)
+}
+
+// major returns the stored indices and values of the k'th row in CSR
+// storage, or column in CSC storage.
+func (m Matrix) major(k int) ([]int, []float64) {
+	if len(m.ptr) == 0 {
+		return nil, nil
+	}
+
+	return m.ind[m.ptr[k]:m.ptr[k+1]], m.val[m.ptr[k]:m.ptr[k+1]]
+}
+
+// At returns the value at row i and column j, or zero outside the
+// Matrix.
+func (m Matrix) At(i int, j int) float64 {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		return 0
+	}
+
+	k, n := i, j
+	if m.storage == CSC {
+		k, n = j, i
+	}
+
+	ind, val := m.major(k)
+	if p, ok := slices.BinarySearch(ind, n); ok {
+		return val[p]
+	}
+
+	return 0
+}
+
+// Row returns the i'th row of the Matrix as a Vector. It is a scan of
+// every column in CSC storage.
+func (m Matrix) Row(i int) Vector {
+	if m.storage == CSC {
+		return m.T().Col(i)
+	}
+
+	ret := NewVector(m.cols)
+	if i >= 0 && i < m.rows {
+		ind, val := m.major(i)
+		for p, n := range ind {
+			ret.data[n] = val[p]
+		}
+	}
+
+	return ret
+}
+
+// Col returns the j'th column of the Matrix as a Vector. It is a scan of
+// every row in CSR storage.
+func (m Matrix) Col(j int) Vector {
+	if m.storage == CSC {
+		return m.T().Row(j)
+	}
+
+	ret := NewVector(m.rows)
+	if j >= 0 && j < m.cols {
+		for i := range m.rows {
+			ind, val := m.major(i)
+			if p, ok := slices.BinarySearch(ind, j); ok {
+				ret.data[i] = val[p]
+			}
+		}
+	}
+
+	return ret
+}
+
+// Rows returns every row of the Matrix as a Vector.
+func (m Matrix) Rows() []Vector {
+	m = m.ToCSR()
+	ret := make([]Vector, m.rows)
+	for i := range ret {
+		ret[i] = m.Row(i)
+	}
+
+	return ret
+}
+
+// T returns the transpose of the Matrix. It shares the Matrix's storage,
+// switching between CSR and CSC, so it takes constant time.
+func (m Matrix) T() Matrix {
+	m.rows, m.cols = m.cols, m.rows
+	m.storage = 1 - m.storage
+	return m
+}
+
+// Clone returns a deep copy of the Matrix, with its own storage. As a
+// Matrix is immutable, its copies and the results of T, ToCSR and
+// ToCSC share its storage safely without one; Clone matches
+// Vector.Clone for code that must not retain the original's storage.
+func (m Matrix) Clone() Matrix {
+	m.ptr = slices.Clone(m.ptr)
+	m.ind = slices.Clone(m.ind)
+	m.val = slices.Clone(m.val)
+	return m
+}
+
+// ToCSR returns the Matrix in CSR storage, converting it if necessary.
+func (m Matrix) ToCSR() Matrix {
+	if m.storage == CSR {
+		return m
+	}
+
+	return m.convert()
+}
+
+// ToCSC returns the Matrix in CSC storage, converting it if necessary.
+func (m Matrix) ToCSC() Matrix {
+	if m.storage == CSC {
+		return m
+	}
+
+	return m.convert()
+}
+
+// convert re-compresses the Matrix along its other axis, switching its
+// storage between CSR and CSC.
+func (m Matrix) convert() Matrix {
+	majors, minors := m.rows, m.cols
+	if m.storage == CSC {
+		majors, minors = minors, majors
+	}
+
+	ret := Matrix{rows: m.rows, cols: m.cols, storage: 1 - m.storage, ptr: make([]int, minors+1)}
+	ret.ind = make([]int, len(m.ind))
+	ret.val = make([]float64, len(m.val))
+	for _, n := range m.ind {
+		ret.ptr[n+1]++
+	}
+
+	for n := range minors {
+		ret.ptr[n+1] += ret.ptr[n]
+	}
+
+	next := slices.Clone(ret.ptr[:minors])
+	for k := range majors {
+		ind, val := m.major(k)
+		for p, n := range ind {
+			ret.ind[next[n]] = k
+			ret.val[next[n]] = val[p]
+			next[n]++
+		}
+	}
+
+	return ret
+}
+
+// MulVec multiplies the Matrix by v, returning a Vector with one
+// dimension per row. MulVec does not check that v's dimensionality
+// matches the number of columns, treating its missing or excess
+// dimensions as zero; see MulVecE for a checked variant.
+func (m Matrix) MulVec(v Vector) Vector {
+	ret := NewVector(m.rows)
+	if m.storage == CSR {
+		for i := range m.rows {
+			ind, val := m.major(i)
+			sum := float64(0)
+			for p, n := range ind {
+				sum += val[p] * v.data[n]
+			}
+
+			if sum != 0 {
+				ret.data[i] = sum
+			}
+		}
+
+		return ret
+	}
+
+	for j, d := range v.data {
+		if j < 0 || j >= m.cols {
+			continue
+		}
+
+		ind, val := m.major(j)
+		for p, i := range ind {
+			ret.data[i] += val[p] * d
+		}
+	}
+
+	return ret.Compact()
+}
+
+// MulVecE multiplies the Matrix by v like MulVec, returning
+// ErrDimensionMismatch unless v's dimensionality matches the number of
+// columns.
+func (m Matrix) MulVecE(v Vector) (Vector, error) {
+	if v.dim != m.cols {
+		return Vector{}, fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v.dim, m.cols)
+	}
+
+	return m.MulVec(v), nil
+}
+
+// MulVecContext multiplies the Matrix by v like MulVecE, as the traced
+// operation "Matrix.MulVec" (see SetTracer). It returns ctx's error
+// without multiplying if ctx is already done.
+func (m Matrix) MulVecContext(ctx context.Context, v Vector) (Vector, error) {
+	var ret Vector
+	err := instrument(ctx, "Matrix.MulVec", func(ctx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var err error
+		ret, err = m.MulVecE(v)
+		return err
+	})
+
+	return ret, err
+}
@@ -0,0 +1,20 @@
+package sparse
+
+import "testing"
+
+func TestMatrixClone(t *testing.T) {
+	m := NewMatrixFromRows([]Vector{NewVectorFromArray([]float64{1, 0, 2}), NewVectorFromArray([]float64{0, 3, 0})})
+	c := m.T().Clone()
+	if r, cols := c.Dims(); r != 3 || cols != 2 || c.Storage() != CSC || c.At(2, 0) != 2 {
+		t.Fatalf("Clone of the transpose is %d×%d in %v with %g at (2, 0)", r, cols, c.Storage(), c.At(2, 0))
+	}
+
+	c.val[0] = 100
+	if m.At(0, 0) != 1 {
+		t.Errorf("writing the Clone's storage changed the original to %g", m.At(0, 0))
+	}
+
+	if z := (Matrix{}).Clone(); z.NNZ() != 0 {
+		t.Errorf("Clone of the zero Matrix has %d entries", z.NNZ())
+	}
+}
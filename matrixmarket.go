@@ -0,0 +1,178 @@
+package sparse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxLineBytes bounds the lines ReadMatrixMarket and ReadLibSVM
+// accept, well beyond bufio.Scanner's default, as a libsvm row with
+// many features easily outgrows it.
+const maxLineBytes = 64 << 20
+
+// maxMatrixMarketRows bounds the rows ReadMatrixMarket returns, so that
+// a corrupt size line cannot have it allocate without limit.
+const maxMatrixMarketRows = 1 << 26
+
+// newLineScanner returns a Scanner over the lines of r of up to
+// maxLineBytes.
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	ret := bufio.NewScanner(r)
+	ret.Buffer(nil, maxLineBytes)
+	return ret
+}
+
+// ReadMatrixMarket reads a MatrixMarket coordinate file, returning one
+// Vector per row with as many dimensions as the matrix has columns. It
+// accepts real, integer and pattern fields, whose entries are read as
+// 1, and expands symmetric and skew-symmetric matrices in full. It
+// returns ErrOutOfRange for a negative size or more than 1<<26 rows,
+// and an error unless the file holds as many entries as its size line
+// gives.
+func ReadMatrixMarket(r io.Reader) ([]Vector, error) {
+	var (
+		rows     []Vector
+		field    string
+		symmetry string
+		sized    bool
+		n, cols  int
+		nnz      int
+		entries  int
+		scanner  = newLineScanner(r)
+	)
+
+	// row returns the i'th row, 0-based, allocating rows as entries
+	// reach them rather than trusting the size line up front.
+	row := func(i int) *Vector {
+		for len(rows) <= i {
+			rows = append(rows, NewVector(cols))
+		}
+
+		return &rows[i]
+	}
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			header := strings.Fields(strings.ToLower(text))
+			if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" || header[2] != "coordinate" {
+				return nil, errors.New("sparse: mtx: unsupported header")
+			}
+
+			field, symmetry = header[3], header[4]
+			switch {
+			case field != "real" && field != "integer" && field != "pattern":
+				return nil, fmt.Errorf("sparse: mtx: unsupported field %q", field)
+			case symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric":
+				return nil, fmt.Errorf("sparse: mtx: unsupported symmetry %q", symmetry)
+			}
+
+			continue
+		}
+
+		if text == "" || strings.HasPrefix(text, "%") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if !sized {
+			if len(fields) != 3 {
+				return nil, fmt.Errorf("sparse: mtx: line %d: expected rows, columns and entries", line)
+			}
+
+			var err1, err2, err3 error
+			n, err1 = strconv.Atoi(fields[0])
+			cols, err2 = strconv.Atoi(fields[1])
+			nnz, err3 = strconv.Atoi(fields[2])
+			if err := errors.Join(err1, err2, err3); err != nil {
+				return nil, fmt.Errorf("sparse: mtx: line %d: %w", line, err)
+			} else if n < 0 || cols < 0 || nnz < 0 || n > maxMatrixMarketRows {
+				return nil, fmt.Errorf("%w: mtx line %d: size %d×%d with %d entries", ErrOutOfRange, line, n, cols, nnz)
+			}
+
+			rows = make([]Vector, 0, min(n, nnz, 1<<16))
+			sized = true
+			continue
+		}
+
+		want := 3
+		if field == "pattern" {
+			want = 2
+		}
+
+		if len(fields) != want {
+			return nil, fmt.Errorf("sparse: mtx: line %d: expected %d fields", line, want)
+		}
+
+		i, err1 := strconv.Atoi(fields[0])
+		j, err2 := strconv.Atoi(fields[1])
+		d, err3 := float64(1), error(nil)
+		if field != "pattern" {
+			d, err3 = strconv.ParseFloat(fields[2], 64)
+		}
+
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return nil, fmt.Errorf("sparse: mtx: line %d: %w", line, err)
+		} else if i < 1 || i > n || j < 1 || j > cols {
+			return nil, fmt.Errorf("%w: mtx line %d: entry (%d, %d)", ErrOutOfRange, line, i, j)
+		} else if entries++; entries > nnz {
+			return nil, fmt.Errorf("sparse: mtx: line %d: more than the %d entries given", line, nnz)
+		}
+
+		row(i-1).Set(j-1, d)
+		if i != j && symmetry != "general" && j <= n && i <= cols {
+			if symmetry == "skew-symmetric" {
+				d = -d
+			}
+
+			row(j-1).Set(i-1, d)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("sparse: mtx: %w", err)
+	} else if !sized {
+		return nil, errors.New("sparse: mtx: missing size line")
+	} else if entries != nnz {
+		return nil, fmt.Errorf("sparse: mtx: %d entries, but the size line gives %d", entries, nnz)
+	}
+
+	if n > 0 {
+		row(n - 1)
+	}
+
+	return rows, nil
+}
+
+// WriteMatrixMarket writes rows as a real, general MatrixMarket
+// coordinate file. The number of columns is the largest row
+// dimensionality, and entries stored outside a row's dimensions are
+// left out.
+func WriteMatrixMarket(w io.Writer, rows []Vector) error {
+	cols, nnz := 0, 0
+	for _, row := range rows {
+		cols = max(cols, row.dim)
+		for n := range row.NonZeros() {
+			if n >= 0 && n < row.dim {
+				nnz++
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "%%MatrixMarket matrix coordinate real general")
+	fmt.Fprintln(bw, len(rows), cols, nnz)
+	for i, row := range rows {
+		for n, d := range row.SortedNonZeros() {
+			if n >= 0 && n < row.dim {
+				fmt.Fprintln(bw, i+1, n+1, strconv.FormatFloat(d, 'g', -1, 64))
+			}
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,55 @@
+package sparse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	mtx := "%%MatrixMarket matrix coordinate real symmetric\n3 3 2\n1 1 2\n3 1 -1\n"
+	rows, err := ReadMatrixMarket(strings.NewReader(mtx))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	if rows[0].Get(0) != 2 || rows[0].Get(2) != -1 || rows[2].Get(0) != -1 || rows[1].NNZ() != 0 {
+		t.Errorf("got rows %v", rows)
+	}
+}
+
+func TestReadMatrixMarketTrailingEmptyRows(t *testing.T) {
+	rows, err := ReadMatrixMarket(strings.NewReader("%%MatrixMarket matrix coordinate real general\n4 2 1\n1 2 5\n"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rows) != 4 || rows[3].Size() != 2 {
+		t.Fatalf("got %d rows, want 4 of dim 2", len(rows))
+	}
+}
+
+func TestReadMatrixMarketBadSize(t *testing.T) {
+	for _, size := range []string{"-1 3 0", "3 -1 0", "3 3 -1", "1099511627776 3 0"} {
+		mtx := "%%MatrixMarket matrix coordinate real general\n" + size + "\n"
+		if _, err := ReadMatrixMarket(strings.NewReader(mtx)); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("size %q: got %v, want ErrOutOfRange", size, err)
+		}
+	}
+}
+
+func TestReadMatrixMarketEntryCount(t *testing.T) {
+	for _, body := range []string{"2 2 1\n1 1 1\n2 2 1\n", "2 2 3\n1 1 1\n"} {
+		mtx := "%%MatrixMarket matrix coordinate real general\n" + body
+		if _, err := ReadMatrixMarket(strings.NewReader(mtx)); err == nil {
+			t.Errorf("%q: got no error for a mismatched entry count", body)
+		}
+	}
+}
+
+func TestReadMatrixMarketOutOfRange(t *testing.T) {
+	mtx := "%%MatrixMarket matrix coordinate real general\n2 2 1\n1 3 1\n"
+	if _, err := ReadMatrixMarket(strings.NewReader(mtx)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("got %v, want ErrOutOfRange", err)
+	}
+}
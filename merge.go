@@ -0,0 +1,104 @@
+package sparse
+
+// Resolver combines the values a and b two Vectors hold at index into
+// the merged value. For merges to be deterministic whatever order nodes
+// exchange Vectors in, it should be commutative and associative, and
+// for repeated merges to be harmless, idempotent as ResolveMax and
+// ResolveMin are.
+type Resolver func(index int, a float64, b float64) float64
+
+// ResolveSum adds the two values, accumulating contributions counted
+// once per node.
+func ResolveSum(_ int, a float64, b float64) float64 {
+	return a + b
+}
+
+// ResolveMax keeps the larger value, as in a grow-only register.
+func ResolveMax(_ int, a float64, b float64) float64 {
+	return max(a, b)
+}
+
+// ResolveMin keeps the smaller value.
+func ResolveMin(_ int, a float64, b float64) float64 {
+	return min(a, b)
+}
+
+// Merge combines two Vectors index by index with resolve, which is
+// called for every index stored in either with zero for a value the
+// other does not store. Indices resolved to zero are left out, and the
+// result takes the larger dimensionality of the two.
+func Merge(v1 Vector, v2 Vector, resolve Resolver) Vector {
+	ret := NewVector(max(v1.dim, v2.dim))
+	union(v1, v2, func(n int, d1 float64, d2 float64) {
+		if d := resolve(n, d1, d2); d != 0 {
+			ret.data[n] = d
+		}
+	})
+
+	return ret
+}
+
+// lwwEntry is a value of an LWWVector and the time it was written.
+type lwwEntry struct {
+	value float64
+	time  int64
+}
+
+// LWWVector is a last-writer-wins register per index: each write
+// carries a timestamp, and merging keeps the latest write to every
+// index. Removals are writes of zero, kept as tombstones so that they
+// win over older writes merged later. Merges are commutative,
+// associative and idempotent, so replicas that exchange LWWVectors in
+// any order converge.
+type LWWVector struct {
+	dim     int
+	entries map[int]lwwEntry
+}
+
+// NewLWWVector constructs an empty LWWVector with dim dimensions.
+func NewLWWVector(dim int) *LWWVector {
+	return &LWWVector{dim: dim, entries: map[int]lwwEntry{}}
+}
+
+// Set the n'th dimension to d at time t, unless it holds a later write.
+// Writes at equal times are ordered by value, so replicas agree on the
+// winner. The LWWVector grows to fit n.
+func (l *LWWVector) Set(n int, d float64, t int64) {
+	if e, ok := l.entries[n]; ok && (e.time > t || (e.time == t && e.value >= d)) {
+		return
+	}
+
+	l.entries[n] = lwwEntry{value: d, time: t}
+	l.dim = max(l.dim, n+1)
+}
+
+// Remove the n'th dimension at time t, unless it holds a later write.
+func (l *LWWVector) Remove(n int, t int64) {
+	l.Set(n, 0, t)
+}
+
+// Get data from the n'th dimension.
+func (l *LWWVector) Get(n int) float64 {
+	return l.entries[n].value
+}
+
+// Merge the writes of other into the LWWVector.
+func (l *LWWVector) Merge(other *LWWVector) {
+	for n, e := range other.entries {
+		l.Set(n, e.value, e.time)
+	}
+
+	l.dim = max(l.dim, other.dim)
+}
+
+// Vector returns the current values as a Vector, without tombstones.
+func (l *LWWVector) Vector() Vector {
+	ret := NewVector(l.dim, WithCapacity(len(l.entries)))
+	for n, e := range l.entries {
+		if e.value != 0 {
+			ret.data[n] = e.value
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,157 @@
+package sparse
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MinHash is a fixed-size sketch of the set of a Vector's non-zero
+// indices. The fraction of positions at which two MinHashes from the
+// same seed agree is an unbiased estimate of the Jaccard index of their
+// sets, with standard error about 1/√k for k hashes.
+type MinHash struct {
+	sig []uint64
+}
+
+// NewMinHash sketches the support of v with k hash functions derived
+// from seed. MinHashes are only comparable if built with the same k
+// and seed. It panics if k is not positive.
+func NewMinHash(v Vector, k int, seed uint64) MinHash {
+	if k <= 0 {
+		panic("sparse: MinHash needs a positive number of hashes")
+	}
+
+	sig := make([]uint64, k)
+	for i := range sig {
+		sig[i] = math.MaxUint64
+	}
+
+	for n := range v.NonZeros() {
+		x := splitmix(uint64(n))
+		for i := range sig {
+			sig[i] = min(sig[i], splitmix(x^(seed+uint64(i))))
+		}
+	}
+
+	return MinHash{sig: sig}
+}
+
+// Signature returns a copy of the sketch's minimum hash values.
+func (m MinHash) Signature() []uint64 {
+	return slices.Clone(m.sig)
+}
+
+// EstimateJaccard estimates the Jaccard index of the sets sketched by m
+// and other, which is 1 for two empty sets as under the Jaccard Metric.
+// It panics unless both have the same number of hashes.
+func (m MinHash) EstimateJaccard(other MinHash) float64 {
+	if len(m.sig) != len(other.sig) {
+		panic(fmt.Errorf("%w: %d hashes != %d", ErrDimensionMismatch, len(m.sig), len(other.sig)))
+	}
+
+	agree := 0
+	for i, x := range m.sig {
+		if x == other.sig[i] {
+			agree++
+		}
+	}
+
+	return float64(agree) / float64(len(m.sig))
+}
+
+// Bands splits the signature into bands of rows hashes each and hashes
+// every band, for locality-sensitive candidate generation: sets with
+// Jaccard index s share at least one band with probability
+// 1 - (1 - s^rows)^bands. It panics unless rows divides the number of
+// hashes.
+func (m MinHash) Bands(rows int) []uint64 {
+	if rows <= 0 || len(m.sig)%rows != 0 {
+		panic(fmt.Sprintf("sparse: cannot split %d hashes into bands of %d", len(m.sig), rows))
+	}
+
+	ret := make([]uint64, len(m.sig)/rows)
+	for b := range ret {
+		x := uint64(b)
+		for _, h := range m.sig[b*rows : (b+1)*rows] {
+			x = splitmix(x ^ h)
+		}
+
+		ret[b] = x
+	}
+
+	return ret
+}
+
+// MinHashIndex finds candidate Vectors with similar supports by banding
+// their MinHash signatures, as LSHIndex does for cosine similarity.
+type MinHashIndex struct {
+	bands, rows int
+	seed        uint64
+	ids         []string
+	tables      []map[uint64][]int
+}
+
+// NewMinHashIndex constructs an empty MinHashIndex sketching Vectors
+// with bands·rows hashes derived from seed. It panics unless bands and
+// rows are positive.
+func NewMinHashIndex(bands int, rows int, seed uint64) *MinHashIndex {
+	if bands <= 0 || rows <= 0 {
+		panic("sparse: MinHash LSH needs positive bands and rows")
+	}
+
+	idx := &MinHashIndex{bands: bands, rows: rows, seed: seed, tables: make([]map[uint64][]int, bands)}
+	for b := range idx.tables {
+		idx.tables[b] = map[uint64][]int{}
+	}
+
+	return idx
+}
+
+// Sketch returns the MinHash of v used by the index.
+func (idx *MinHashIndex) Sketch(v Vector) MinHash {
+	return NewMinHash(v, idx.bands*idx.rows, idx.seed)
+}
+
+// Add v to the index under id.
+func (idx *MinHashIndex) Add(id string, v Vector) {
+	i := len(idx.ids)
+	idx.ids = append(idx.ids, id)
+	for b, key := range idx.Sketch(v).Bands(idx.rows) {
+		idx.tables[b][key] = append(idx.tables[b][key], i)
+	}
+}
+
+// AddAll adds each of vs to the index under the id at the same
+// position, as the traced operation "MinHashIndex.AddAll" (see
+// SetTracer). If ctx is done part way through, it stops and returns
+// ctx's error, leaving the Vectors added so far in the index.
+func (idx *MinHashIndex) AddAll(ctx context.Context, ids []string, vs []Vector) error {
+	return addAll(ctx, "MinHashIndex.AddAll", ids, vs, idx.Add)
+}
+
+// Len is the number of Vectors in the index.
+func (idx *MinHashIndex) Len() int {
+	return len(idx.ids)
+}
+
+// Candidates returns the IDs of the Vectors sharing at least one band
+// with query, in the order they were added.
+func (idx *MinHashIndex) Candidates(query Vector) []string {
+	seen := map[int]bool{}
+	for b, key := range idx.Sketch(query).Bands(idx.rows) {
+		for _, i := range idx.tables[b][key] {
+			seen[i] = true
+		}
+	}
+
+	var ret []string
+	for i, id := range idx.ids {
+		if seen[i] {
+			ret = append(ret, id)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,57 @@
+package sparse
+
+import "math"
+
+// L1 is the Manhattan norm of the Vector, the sum of its absolute
+// values.
+func (v Vector) L1() float64 {
+	ret := float64(0)
+	for _, d := range v.data {
+		ret += math.Abs(d)
+	}
+
+	return ret
+}
+
+// LInf is the maximum norm of the Vector, its largest absolute value.
+func (v Vector) LInf() float64 {
+	ret := float64(0)
+	for _, d := range v.data {
+		ret = max(ret, math.Abs(d))
+	}
+
+	return ret
+}
+
+// Norm is the Lp norm of the Vector. Norm(2) is Magnitude, and
+// Norm(math.Inf(1)) is LInf. It is NaN unless p is positive.
+func (v Vector) Norm(p float64) float64 {
+	switch {
+	case !(p > 0):
+		return math.NaN()
+	case p == 1:
+		return v.L1()
+	case p == 2:
+		return v.Magnitude()
+	case math.IsInf(p, 1):
+		return v.LInf()
+	}
+
+	ret := float64(0)
+	for _, d := range v.data {
+		ret += math.Pow(math.Abs(d), p)
+	}
+
+	return math.Pow(ret, 1/p)
+}
+
+// Normalize returns the Vector scaled to unit Lp norm. A Vector whose
+// norm is zero is returned as an unscaled copy.
+func (v Vector) Normalize(p float64) Vector {
+	norm := v.Norm(p)
+	if norm == 0 {
+		return v.Clone()
+	}
+
+	return v.Times(1 / norm)
+}
@@ -0,0 +1,93 @@
+package sparse
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// normCache holds a Vector's cached norms. It is shared by copies of the
+// Vector, as its entries are, and invalidated by every mutation.
+type normCache struct {
+	valid atomic.Bool
+	l1    atomic.Uint64
+	l2    atomic.Uint64
+}
+
+// normCacheHits and normCacheMisses count the lookups of every
+// normCache, for NormCacheStats.
+var normCacheHits, normCacheMisses atomic.Uint64
+
+// NormCacheStats returns how many norm lookups on Vectors constructed
+// with WithCachedNorms have been served from their cache, and how many
+// missed it and recomputed the norms, since the program started. It
+// lets callers export the cache's hit rate (see the sparseprom
+// package).
+func NormCacheStats() (hits uint64, misses uint64) {
+	return normCacheHits.Load(), normCacheMisses.Load()
+}
+
+// WithCachedNorms caches the Vector's magnitude and L1 norm for
+// CachedMagnitude and CachedL1, recomputing them only after the Vector
+// is mutated.
+func WithCachedNorms() Option {
+	return func(o *options) {
+		o.cacheNorms = true
+	}
+}
+
+// newNormCache returns a fresh cache if o asks for one.
+func (o *options) newNormCache() *normCache {
+	if o == nil || !o.cacheNorms {
+		return nil
+	}
+
+	return &normCache{}
+}
+
+// invalidate discards the Vector's cached norms after a mutation.
+func (v *Vector) invalidate() {
+	if v.norms != nil {
+		v.norms.valid.Store(false)
+	}
+}
+
+// cachedNorms returns the Vector's L1 and L2 norms, computing and
+// caching them if needed.
+func (v Vector) cachedNorms() (float64, float64) {
+	if v.norms == nil {
+		return v.L1(), v.Magnitude()
+	} else if v.norms.valid.Load() {
+		normCacheHits.Add(1)
+		return math.Float64frombits(v.norms.l1.Load()), math.Float64frombits(v.norms.l2.Load())
+	}
+
+	normCacheMisses.Add(1)
+	l1, l2 := v.L1(), v.Magnitude()
+	v.norms.l1.Store(math.Float64bits(l1))
+	v.norms.l2.Store(math.Float64bits(l2))
+	v.norms.valid.Store(true)
+	return l1, l2
+}
+
+// CachedMagnitude is Magnitude, served from a cache for Vectors
+// constructed with WithCachedNorms. It is safe to call concurrently with
+// other reads.
+func (v Vector) CachedMagnitude() float64 {
+	if v.norms == nil {
+		return v.Magnitude()
+	}
+
+	_, l2 := v.cachedNorms()
+	return l2
+}
+
+// CachedL1 is L1, served from a cache for Vectors constructed with
+// WithCachedNorms.
+func (v Vector) CachedL1() float64 {
+	if v.norms == nil {
+		return v.L1()
+	}
+
+	l1, _ := v.cachedNorms()
+	return l1
+}
@@ -0,0 +1,56 @@
+package sparse
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestCachedMagnitude(t *testing.T) {
+	v := NewVectorFromArray([]float64{3, 4}, WithCachedNorms())
+	if v.CachedMagnitude() != 5 || v.CachedL1() != 7 {
+		t.Fatalf("CachedMagnitude, CachedL1 = %g, %g", v.CachedMagnitude(), v.CachedL1())
+	}
+
+	v.Set(0, 0)
+	if v.CachedMagnitude() != 4 {
+		t.Fatalf("CachedMagnitude after Set = %g, want 4", v.CachedMagnitude())
+	}
+
+	w := NewVectorFromArray([]float64{3, 4})
+	if w.CachedMagnitude() != 5 || w.CachedL1() != 7 {
+		t.Fatalf("uncached CachedMagnitude, CachedL1 = %g, %g", w.CachedMagnitude(), w.CachedL1())
+	}
+
+	if got := Similarity(v, w); math.Abs(got-0.8) > 1e-12 {
+		t.Fatalf("Similarity() = %g, want 0.8", got)
+	}
+}
+
+func TestMemoryBytes(t *testing.T) {
+	var empty Vector
+	if got := empty.MemoryBytes(); got != int(unsafe.Sizeof(Vector{})) {
+		t.Fatalf("zero Vector MemoryBytes() = %d, want %d", got, unsafe.Sizeof(Vector{}))
+	}
+
+	plain := NewVectorFromArray([]float64{1, 2})
+	cached := NewVectorFromArray([]float64{1, 2}, WithCachedNorms())
+	if got, want := cached.MemoryBytes()-plain.MemoryBytes(), int(unsafe.Sizeof(normCache{})); got != want {
+		t.Fatalf("norm cache adds %d bytes, want %d", got, want)
+	}
+}
+
+func TestNormCacheStats(t *testing.T) {
+	hits, misses := NormCacheStats()
+	v := NewVectorFromArray([]float64{3, 4}, WithCachedNorms())
+	v.CachedMagnitude() // miss
+	v.CachedL1()        // hit
+	v.Set(1, 0)
+	v.CachedMagnitude() // miss
+	NewVectorFromArray([]float64{1}).CachedMagnitude()
+
+	gotHits, gotMisses := NormCacheStats()
+	if gotHits-hits != 1 || gotMisses-misses != 2 {
+		t.Errorf("NormCacheStats() moved by %d hits and %d misses, want 1 and 2", gotHits-hits, gotMisses-misses)
+	}
+}
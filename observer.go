@@ -0,0 +1,53 @@
+package sparse
+
+// Observer is notified of mutations to a Vector, for dirty-tracking,
+// replication or cache invalidation. Its methods are called
+// synchronously, after the mutation has been applied.
+type Observer interface {
+	// Changed is called when the n'th dimension is written, with its
+	// previous value, which is zero if none was stored.
+	Changed(n int, old float64, new float64)
+
+	// Removed is called when the stored entry on the n'th dimension is
+	// removed, including by writes that drop it.
+	Removed(n int, old float64)
+
+	// Cleared is called when all entries are removed at once.
+	Cleared()
+}
+
+// WithObserver notifies obs of mutations made through Set, SetChecked,
+// Load, Remove and Clear, and by the in-place operations and Journal
+// rollbacks that write through them. Copies of the Vector by assignment
+// share its Observer, as they share its entries, but Clones and the
+// results of arithmetic on it do not: their mutations cannot affect
+// the original, so they are not reported as its own.
+func WithObserver(obs Observer) Option {
+	return func(o *options) {
+		o.observer = obs
+	}
+}
+
+// withoutObserver returns o without its Observer, for Vectors derived
+// from one that has it. It is nil if no other option remains.
+func (o *options) withoutObserver() *options {
+	if o == nil || o.observer == nil {
+		return o
+	}
+
+	ret := *o
+	ret.observer = nil
+	if ret == (options{}) {
+		return nil
+	}
+
+	return &ret
+}
+
+func (o *options) observerOf() Observer {
+	if o == nil {
+		return nil
+	}
+
+	return o.observer
+}
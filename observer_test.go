@@ -0,0 +1,115 @@
+package sparse
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+// logObserver records each notification as a string.
+type logObserver struct {
+	log []string
+}
+
+func (o *logObserver) Changed(n int, old float64, new float64) {
+	o.log = append(o.log, fmt.Sprintf("changed %d %g->%g", n, old, new))
+}
+
+func (o *logObserver) Removed(n int, old float64) {
+	o.log = append(o.log, fmt.Sprintf("removed %d %g", n, old))
+}
+
+func (o *logObserver) Cleared() {
+	o.log = append(o.log, "cleared")
+}
+
+func TestObserverSeesInPlaceOperations(t *testing.T) {
+	obs := &logObserver{}
+	v := NewVector(3, WithObserver(obs), WithTolerance(0.01))
+	v.Set(0, 1)
+	v.AddInPlace(NewVectorFromArray([]float64{-0.995, 2, 0}))
+	v.ScaleInPlace(2)
+	Implementation().Axpy(1, NewVectorFromArray([]float64{0, 0, 3}), &v)
+
+	want := []string{
+		"changed 0 0->1",
+		"changed 1 0->2",
+		"removed 0 1",
+		"changed 1 2->4",
+		"changed 2 0->3",
+	}
+
+	slices.Sort(obs.log[1:3]) // AddInPlace visits entries in no particular order
+	if !slices.Equal(obs.log, want) {
+		t.Fatalf("notifications:\n%q\nwant:\n%q", obs.log, want)
+	}
+}
+
+func TestInPlaceOperationsApplyNaNPolicy(t *testing.T) {
+	v := NewVector(2, WithNaNPolicy(NaNReject))
+	v.Set(0, 1)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("ScaleInPlace(NaN) did not panic under NaNReject")
+		}
+	}()
+
+	v.ScaleInPlace(math.NaN())
+}
+
+func TestJournalRollbackNotifies(t *testing.T) {
+	obs := &logObserver{}
+	v := NewVector(3, WithObserver(obs))
+	v.Set(0, 1)
+	j := NewJournal(&v)
+	j.Checkpoint()
+	j.Set(0, 5)
+	j.Set(2, 7)
+	obs.log = nil
+
+	j.Rollback()
+	want := []string{"removed 2 7", "changed 0 5->1"}
+	if !slices.Equal(obs.log, want) {
+		t.Fatalf("notifications %q, want %q", obs.log, want)
+	} else if v.Get(0) != 1 || v.Has(2) {
+		t.Fatalf("after Rollback: %v", v)
+	}
+}
+
+func TestBuildSkipsZeros(t *testing.T) {
+	obs := &logObserver{}
+	v, err := NewVectorBuilder(WithObserver(obs)).Dim(3).Add(1, 2).Add(1, -2).Build()
+	if err != nil {
+		t.Fatal(err)
+	} else if len(obs.log) != 0 || v.NNZ() != 0 {
+		t.Fatalf("Build of a cancelled entry: notified %q, NNZ %d", obs.log, v.NNZ())
+	}
+
+	if _, err := NewVectorBuilder().Dim(3).Set(5, 0).Build(); err == nil {
+		t.Fatal("Build with an out-of-range zero entry: want ErrOutOfRange")
+	}
+}
+
+func TestCloneDropsObserver(t *testing.T) {
+	obs := &logObserver{}
+	v := NewVector(3, WithObserver(obs), WithTolerance(0.01))
+	v.Set(0, 1)
+	obs.log = nil
+
+	c := v.Clone()
+	c.Set(1, 2)
+	c.Set(2, 0.001) // still dropped by the tolerance the Clone keeps
+	c.Remove(0)
+	c.Clear()
+
+	sum := Add(NewVectorFromArray([]float64{0, 1, 0}), v) // a Clone of v, on the tie
+	sum.Set(2, 5)
+	e := Expr(v).Scale(2).Eval()
+	e.Set(2, 5)
+	if len(obs.log) != 0 {
+		t.Errorf("mutating copies notified the original's Observer: %q", obs.log)
+	} else if c.Has(2) {
+		t.Error("the Clone lost the original's tolerance")
+	}
+}
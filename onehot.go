@@ -0,0 +1,145 @@
+package sparse
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrUnseenCategory is returned when a OneHotEncoder meets a category it
+// was not fitted with and its policy is UnseenError.
+var ErrUnseenCategory = errors.New("sparse: unseen category")
+
+// UnseenPolicy is how a OneHotEncoder treats categories it was not
+// fitted with.
+type UnseenPolicy int
+
+const (
+	// UnseenIgnore encodes an unseen category as all zeros. It is the
+	// default.
+	UnseenIgnore UnseenPolicy = iota
+
+	// UnseenError rejects unseen categories with ErrUnseenCategory.
+	UnseenError
+
+	// UnseenHash hashes unseen categories into a fixed number of extra
+	// bucket dimensions after each field's levels.
+	UnseenHash
+)
+
+// OneHotEncoder encodes rows of categorical fields as Vectors with one
+// dimension per level of each field, as learned by Fit. A row's Vector
+// is the Concat of one one-hot Vector per field, so it can itself be
+// concatenated with other features.
+type OneHotEncoder struct {
+	unseen  UnseenPolicy
+	hasher  Hasher
+	levels  [][]string
+	index   []map[string]int
+	offsets []int // offsets[i] is the first dimension of field i
+}
+
+// NewOneHotEncoder constructs an unfitted OneHotEncoder treating unseen
+// categories according to unseen. Under UnseenHash each field gets
+// buckets extra dimensions; it panics if buckets is not positive.
+func NewOneHotEncoder(unseen UnseenPolicy, buckets int) *OneHotEncoder {
+	e := &OneHotEncoder{unseen: unseen}
+	if unseen == UnseenHash {
+		e.hasher = NewHasher(buckets)
+	}
+
+	return e
+}
+
+// Fit learns the levels of each field from rows, replacing any learned
+// before. Levels are numbered in lexical order. It returns
+// ErrDimensionMismatch unless every row has the same number of fields.
+func (e *OneHotEncoder) Fit(rows [][]string) error {
+	fields := 0
+	if len(rows) > 0 {
+		fields = len(rows[0])
+	}
+
+	seen := make([]map[string]bool, fields)
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+
+	for _, row := range rows {
+		if len(row) != fields {
+			return fmt.Errorf("%w: row of %d fields, want %d", ErrDimensionMismatch, len(row), fields)
+		}
+
+		for i, c := range row {
+			seen[i][c] = true
+		}
+	}
+
+	e.levels = make([][]string, fields)
+	e.index = make([]map[string]int, fields)
+	e.offsets = make([]int, fields+1)
+	for i := range fields {
+		e.levels[i] = make([]string, 0, len(seen[i]))
+		for c := range seen[i] {
+			e.levels[i] = append(e.levels[i], c)
+		}
+
+		slices.Sort(e.levels[i])
+		e.index[i] = make(map[string]int, len(e.levels[i]))
+		for n, c := range e.levels[i] {
+			e.index[i][c] = n
+		}
+
+		e.offsets[i+1] = e.offsets[i] + len(e.levels[i]) + e.hasher.Size()
+	}
+
+	return nil
+}
+
+// Fields is the number of fields the OneHotEncoder was fitted with.
+func (e *OneHotEncoder) Fields() int {
+	return len(e.levels)
+}
+
+// Size is the dimensionality of the Vectors the OneHotEncoder produces.
+func (e *OneHotEncoder) Size() int {
+	if len(e.offsets) == 0 {
+		return 0
+	}
+
+	return e.offsets[len(e.offsets)-1]
+}
+
+// Levels returns the learned levels of the i'th field, in the order of
+// their dimensions.
+func (e *OneHotEncoder) Levels(i int) []string {
+	return slices.Clone(e.levels[i])
+}
+
+// Transform encodes row, which must have one category per field. It
+// returns ErrDimensionMismatch if it does not, and ErrUnseenCategory
+// for a category the OneHotEncoder was not fitted with under
+// UnseenError.
+func (e *OneHotEncoder) Transform(row []string) (Vector, error) {
+	if len(row) != len(e.levels) {
+		return Vector{}, fmt.Errorf("%w: row of %d fields, want %d", ErrDimensionMismatch, len(row), len(e.levels))
+	}
+
+	ret := NewVector(e.Size(), WithCapacity(len(row)))
+	for i, c := range row {
+		if n, ok := e.index[i][c]; ok {
+			ret.data[e.offsets[i]+n] = 1
+			continue
+		}
+
+		switch e.unseen {
+		case UnseenError:
+			return Vector{}, fmt.Errorf("%w: %q in field %d", ErrUnseenCategory, c, i)
+		case UnseenHash:
+			n, _ := e.hasher.Index(c)
+			ret.data[e.offsets[i]+len(e.levels[i])+n]++
+		}
+	}
+
+	return ret, nil
+}
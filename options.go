@@ -0,0 +1,155 @@
+package sparse
+
+// Option configures a Vector at construction.
+type Option func(*options)
+
+// options are the settings of a Vector. A nil *options is the default.
+type options struct {
+	bounds     Bounds
+	capacity   int
+	tolerance  float64
+	nans       NaNPolicy
+	dups       DuplicatePolicy
+	observer   Observer
+	cacheNorms bool
+	pool       *Pool
+}
+
+// Bounds is how a Vector treats writes outside its dimensions.
+type Bounds int
+
+const (
+	// Lenient stores out-of-range writes without changing the Vector's
+	// dimensionality. It is the default, and matches the behaviour of
+	// Set before bounds were configurable.
+	Lenient Bounds = iota
+
+	// Strict rejects out-of-range writes: SetChecked returns an error
+	// and Set panics.
+	Strict
+
+	// AutoGrow grows the Vector to fit writes beyond its dimensions.
+	AutoGrow
+)
+
+// NaNPolicy is how a Vector treats writes of NaN.
+type NaNPolicy int
+
+const (
+	// NaNAllow stores NaNs like any other value. It is the default.
+	NaNAllow NaNPolicy = iota
+
+	// NaNReject rejects NaNs: SetChecked returns ErrNaN and Set panics.
+	NaNReject
+
+	// NaNDrop treats NaNs as zero, removing the entry.
+	NaNDrop
+)
+
+// DuplicatePolicy is how constructors that take index/value pairs
+// treat an index given more than once.
+type DuplicatePolicy int
+
+const (
+	// DuplicateError rejects repeated indices with ErrDuplicateIndex. It
+	// is the default.
+	DuplicateError DuplicatePolicy = iota
+
+	// DuplicateSum sums the values of repeated indices, as CSR and COO
+	// sources conventionally do.
+	DuplicateSum
+)
+
+// WithBounds sets how the Vector treats writes outside its dimensions.
+func WithBounds(b Bounds) Option {
+	return func(o *options) {
+		o.bounds = b
+	}
+}
+
+// WithCapacity sizes the Vector's storage for n entries up front.
+func WithCapacity(n int) Option {
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// WithTolerance treats writes whose magnitude is at most eps as zero,
+// removing the entry instead of storing it.
+func WithTolerance(eps float64) Option {
+	return func(o *options) {
+		o.tolerance = eps
+	}
+}
+
+// WithNaNPolicy sets how the Vector treats writes of NaN.
+func WithNaNPolicy(p NaNPolicy) Option {
+	return func(o *options) {
+		o.nans = p
+	}
+}
+
+// WithDuplicates sets how constructors that take index/value pairs
+// treat repeated indices.
+func WithDuplicates(p DuplicatePolicy) Option {
+	return func(o *options) {
+		o.dups = p
+	}
+}
+
+// newOptions applies opts over the defaults, returning nil if there are
+// none so default Vectors carry no settings.
+func newOptions(opts []Option) *options {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+func (o *options) boundsMode() Bounds {
+	if o == nil {
+		return Lenient
+	}
+
+	return o.bounds
+}
+
+func (o *options) capacityHint() int {
+	if o == nil {
+		return 0
+	}
+
+	return o.capacity
+}
+
+func (o *options) nanPolicy() NaNPolicy {
+	if o == nil {
+		return NaNAllow
+	}
+
+	return o.nans
+}
+
+func (o *options) duplicatePolicy() DuplicatePolicy {
+	if o == nil {
+		return DuplicateError
+	}
+
+	return o.dups
+}
+
+// dropped reports whether writing d should remove an entry rather than
+// store it.
+func (o *options) dropped(d float64) bool {
+	if o == nil {
+		return false
+	}
+
+	return (o.nans == NaNDrop && d != d) || (o.tolerance > 0 && -o.tolerance <= d && d <= o.tolerance)
+}
@@ -0,0 +1,61 @@
+package sparse
+
+// orthoTolerance is the fraction of a Vector's magnitude below which
+// Orthogonalize treats what remains of it as rounding error.
+const orthoTolerance = 1e-10
+
+// Project returns the projection of v onto the direction of onto, with
+// the larger dimensionality of the two. Projecting onto a zero vector
+// yields a zero vector.
+func Project(v Vector, onto Vector) Vector {
+	ret := NewVector(max(v.dim, onto.dim))
+	if sq := Dot(onto, onto); sq != 0 {
+		ret.AddScaledInPlace(Dot(v, onto)/sq, onto)
+	}
+
+	return ret
+}
+
+// Reject returns the rejection of v from onto: the component of v
+// orthogonal to onto, v - Project(v, onto), with the larger
+// dimensionality of the two.
+func Reject(v Vector, onto Vector) Vector {
+	ret := v.Clone()
+	ret.Grow(onto.dim)
+	if sq := Dot(onto, onto); sq != 0 {
+		ret.AddScaledInPlace(-Dot(v, onto)/sq, onto)
+	}
+
+	return ret.Compact()
+}
+
+// Orthogonalize returns an orthonormal basis for the span of vs by the
+// modified Gram-Schmidt process, in the order of vs. A Vector that is
+// linearly dependent on those before it contributes nothing, so the
+// basis is shorter than vs if they are not linearly independent. Each
+// Vector in the basis has the largest dimensionality among vs.
+func Orthogonalize(vs []Vector) []Vector {
+	dim := 0
+	for _, v := range vs {
+		dim = max(dim, v.dim)
+	}
+
+	var basis []Vector
+	for _, v := range vs {
+		r := v.Clone()
+		r.Grow(dim)
+		for _, q := range basis {
+			r.AddScaledInPlace(-Dot(r, q), q)
+		}
+
+		norm := r.Magnitude()
+		if norm <= orthoTolerance*v.Magnitude() {
+			continue
+		}
+
+		r.ScaleInPlace(1 / norm)
+		basis = append(basis, r.Compact())
+	}
+
+	return basis
+}
@@ -0,0 +1,86 @@
+package sparse
+
+// sortedInRange returns the indices and values of v's non-zero entries
+// within its dimensions, in ascending index order.
+func sortedInRange(v Vector) ([]int, []float64) {
+	var (
+		ind []int
+		val []float64
+	)
+
+	for n, d := range v.SortedNonZeros() {
+		if n >= 0 && n < v.dim {
+			ind = append(ind, n)
+			val = append(val, d)
+		}
+	}
+
+	return ind, val
+}
+
+// Outer returns the outer product v1v2ᵀ of two Vectors as a rank-1 CSR
+// Matrix with a row per dimension of v1 and a column per dimension of
+// v2. It stores the product of every pair of their non-zero entries.
+func Outer(v1 Vector, v2 Vector) Matrix {
+	return Matrix{}.AddOuter(1, v1, v2)
+}
+
+// AddOuter returns the Matrix plus alpha times the outer product xyᵀ,
+// in CSR storage, leaving the Matrix unchanged. The result grows to fit
+// x's dimensions in its rows and y's in its columns if they are larger.
+// It copies the Matrix, so when accumulating many updates, collecting
+// triplets for NewMatrixFromTriplets is cheaper.
+func (m Matrix) AddOuter(alpha float64, x Vector, y Vector) Matrix {
+	m = m.ToCSR()
+	xind, xval := sortedInRange(x)
+	yind, yval := sortedInRange(y)
+	ret := Matrix{
+		rows: max(m.rows, x.dim),
+		cols: max(m.cols, y.dim),
+		ptr:  make([]int, 1, max(m.rows, x.dim)+1),
+		ind:  make([]int, 0, len(m.ind)+len(xind)*len(yind)),
+		val:  make([]float64, 0, len(m.val)+len(xind)*len(yind)),
+	}
+
+	k := 0
+	for i := range ret.rows {
+		var ind []int
+		var val []float64
+		if i < m.rows {
+			ind, val = m.major(i)
+		}
+
+		scale := float64(0)
+		if k < len(xind) && xind[k] == i {
+			scale = alpha * xval[k]
+			k++
+		}
+
+		// Merge the row with scale times y, both in ascending order.
+		p, q := 0, 0
+		for p < len(ind) || (scale != 0 && q < len(yind)) {
+			switch {
+			case scale == 0 || q == len(yind) || (p < len(ind) && ind[p] < yind[q]):
+				ret.ind = append(ret.ind, ind[p])
+				ret.val = append(ret.val, val[p])
+				p++
+			case p == len(ind) || yind[q] < ind[p]:
+				ret.ind = append(ret.ind, yind[q])
+				ret.val = append(ret.val, scale*yval[q])
+				q++
+			default:
+				if d := val[p] + scale*yval[q]; d != 0 {
+					ret.ind = append(ret.ind, ind[p])
+					ret.val = append(ret.val, d)
+				}
+
+				p++
+				q++
+			}
+		}
+
+		ret.ptr = append(ret.ptr, len(ret.ind))
+	}
+
+	return ret
+}
@@ -0,0 +1,75 @@
+package sparse
+
+// Patch is the difference between two versions of a Vector: the entries
+// that changed, with removed entries recorded as zeros, and the new
+// dimensionality. It is typically far smaller than the Vector, so it is
+// cheap to log or transmit. The zero value is an empty Patch that
+// changes nothing but resets the dimensionality to zero.
+type Patch struct {
+	changes Vector
+}
+
+// Diff returns the Patch that turns old into new.
+func Diff(old Vector, new Vector) Patch {
+	changes := NewVector(new.dim)
+
+	// Apply drops entries beyond the new dimensionality itself.
+	for n, d := range old.data {
+		if d != 0 && new.data[n] == 0 && n >= 0 && n < new.dim {
+			changes.data[n] = 0
+		}
+	}
+
+	for n, d := range new.data {
+		if d != 0 && old.data[n] != d {
+			changes.data[n] = d
+		}
+	}
+
+	return Patch{changes: changes}
+}
+
+// Apply returns v with the Patch p applied, leaving v unchanged. If the
+// Patch shrinks v, entries beyond its new dimensions are dropped.
+// Applying Diff(old, new) to old yields new, without its stored zeros.
+func Apply(v Vector, p Patch) Vector {
+	ret := v.Clone()
+	ret.dim = p.changes.dim
+	ret.lazyInit()
+	for n := range ret.data {
+		if n >= ret.dim && n < v.dim {
+			delete(ret.data, n)
+		}
+	}
+
+	for n, d := range p.changes.data {
+		if d == 0 {
+			delete(ret.data, n)
+		} else {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
+
+// Len is the number of entries the Patch changes.
+func (p Patch) Len() int {
+	return len(p.changes.data)
+}
+
+// Size is the dimensionality the Patch gives a Vector.
+func (p Patch) Size() int {
+	return p.changes.dim
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, in the encoding of
+// Vector.MarshalBinary with removals stored as zeros.
+func (p Patch) MarshalBinary() ([]byte, error) {
+	return p.changes.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Patch) UnmarshalBinary(buf []byte) error {
+	return p.changes.UnmarshalBinary(buf)
+}
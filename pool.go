@@ -0,0 +1,56 @@
+package sparse
+
+import "sync"
+
+// Pool recycles the storage of short-lived Vectors, to cut allocation
+// and GC pressure in pipelines that produce many of them. Vectors
+// constructed with WithPool draw their storage from the Pool, as do
+// their Clones and the results of operations built on Clone, such as
+// Add and Times; Release returns it. The zero value is an empty Pool
+// ready to use, and a Pool is safe for concurrent use.
+type Pool struct {
+	maps sync.Pool
+}
+
+// WithPool draws the Vector's storage, and that of its Clones and the
+// Vectors derived from them, from p.
+func WithPool(p *Pool) Option {
+	return func(o *options) {
+		o.pool = p
+	}
+}
+
+// get returns empty storage from the Pool, or new storage sized for
+// capacity entries if it has none.
+func (p *Pool) get(capacity int) map[int]float64 {
+	if m, ok := p.maps.Get().(map[int]float64); ok {
+		return m
+	}
+
+	return make(map[int]float64, capacity)
+}
+
+// newMap returns empty storage for capacity entries, from o's Pool if
+// it has one.
+func (o *options) newMap(capacity int) map[int]float64 {
+	if o == nil || o.pool == nil {
+		return make(map[int]float64, capacity)
+	}
+
+	return o.pool.get(capacity)
+}
+
+// Release returns the Vector's storage to the Pool it was constructed
+// with, leaving it empty. Copies of the Vector share that storage, so
+// none may be used after Release. It is a no-op for a Vector without a
+// Pool.
+func (v *Vector) Release() {
+	if v.opts == nil || v.opts.pool == nil || v.data == nil {
+		return
+	}
+
+	clear(v.data)
+	v.opts.pool.maps.Put(v.data)
+	v.data = nil
+	v.invalidate()
+}
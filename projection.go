@@ -0,0 +1,92 @@
+package sparse
+
+import (
+	"fmt"
+	"math"
+)
+
+// RandomProjection reduces Vectors of any dimensionality to a fixed,
+// smaller one while approximately preserving their distances and inner
+// products, in the manner of the Johnson–Lindenstrauss lemma.
+//
+// The projection matrix is sparse in the style of Achlioptas: each
+// entry is zero, or ±1/√(density·dims) with probability density/2
+// each. Entries are derived from a hash of the seed, row and column
+// rather than stored, so projecting a Vector costs O(nnz·dims) whatever
+// its dimensionality.
+type RandomProjection struct {
+	dim       int
+	threshold uint64 // hashes below threshold are non-zero entries
+	scale     float64
+	seed      uint64
+}
+
+// NewRandomProjection constructs a RandomProjection onto dim dimensions
+// whose matrix has the given density, seeding it with seed. Achlioptas
+// proposed a density of 1/3; densities as low as 1/√d for inputs of
+// dimensionality d preserve distances almost as well, and project
+// faster. It panics unless dim is positive and density is in (0, 1].
+func NewRandomProjection(dim int, density float64, seed uint64) RandomProjection {
+	if dim <= 0 || !(density > 0 && density <= 1) {
+		panic(fmt.Sprintf("sparse: cannot project onto %d dimensions with density %g", dim, density))
+	}
+
+	threshold := uint64(math.MaxUint64)
+	if density < 1 {
+		threshold = uint64(density * (1 << 64))
+	}
+
+	return RandomProjection{
+		dim:       dim,
+		threshold: threshold,
+		scale:     1 / math.Sqrt(density*float64(dim)),
+		seed:      seed,
+	}
+}
+
+// Size is the number of dimensions the RandomProjection maps onto.
+func (p RandomProjection) Size() int {
+	return p.dim
+}
+
+// At is the entry of the projection matrix at row i and column j,
+// which is the weight of input dimension j in output dimension i.
+func (p RandomProjection) At(i int, j int) float64 {
+	x := splitmix(splitmix(p.seed^uint64(j)) + uint64(i))
+	switch {
+	case x >= p.threshold:
+		return 0
+	case x&1 == 0:
+		return p.scale
+	default:
+		return -p.scale
+	}
+}
+
+// TransformDense projects v, returning its dense image.
+func (p RandomProjection) TransformDense(v Vector) []float64 {
+	ret := make([]float64, p.dim)
+	for n, d := range v.data {
+		if d == 0 {
+			continue
+		}
+
+		col := splitmix(p.seed ^ uint64(n))
+		for i := range ret {
+			if x := splitmix(col + uint64(i)); x < p.threshold {
+				if x&1 == 0 {
+					ret[i] += p.scale * d
+				} else {
+					ret[i] -= p.scale * d
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
+// Transform projects v, returning its image as a Vector.
+func (p RandomProjection) Transform(v Vector) Vector {
+	return FromDense(p.TransformDense(v))
+}
@@ -0,0 +1,236 @@
+package sparse
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// Precision is the storage format of a QuantizedVector's values.
+type Precision int
+
+const (
+	// Int8 stores each value as a signed byte scaled by the largest
+	// magnitude in the Vector, so values are accurate to within 1/254 of
+	// it. Entries much smaller than the largest may round to zero and
+	// are then left out.
+	Int8 Precision = iota
+
+	// Float16 stores each value as an IEEE 754 half-precision float,
+	// accurate to about three significant digits for magnitudes between
+	// 2⁻¹⁴ and 65504. Larger magnitudes overflow to ±Inf, and much
+	// smaller ones round to zero and are left out.
+	Float16
+)
+
+// QuantizedVector is an immutable sparse vector storing its values at
+// reduced precision, taking a quarter (Float16) or an eighth (Int8) of
+// the space of float64 values. Build one with Vector.Quantize. The zero
+// value is an empty, zero-dimensional QuantizedVector.
+type QuantizedVector struct {
+	dim       int
+	precision Precision
+	scale     float64 // the value of one Int8 step
+	indices   []int
+	int8s     []int8
+	float16s  []uint16
+}
+
+// Quantize converts the Vector to a QuantizedVector of the given
+// precision, leaving out stored zeros and entries outside its
+// dimensions. Int8 quantization needs finite values.
+func (v Vector) Quantize(p Precision) QuantizedVector {
+	c := v.Compress()
+	ret := QuantizedVector{dim: c.dim, precision: p}
+	switch p {
+	case Int8:
+		largest := float64(0)
+		for _, d := range c.values {
+			largest = max(largest, math.Abs(d))
+		}
+
+		ret.scale = largest / 127
+		for i, d := range c.values {
+			if q := int8(math.Round(d / ret.scale)); q != 0 {
+				ret.indices = append(ret.indices, c.indices[i])
+				ret.int8s = append(ret.int8s, q)
+			}
+		}
+	case Float16:
+		for i, d := range c.values {
+			if h := float16Bits(d); h&0x7fff != 0 {
+				ret.indices = append(ret.indices, c.indices[i])
+				ret.float16s = append(ret.float16s, h)
+			}
+		}
+	}
+
+	return ret
+}
+
+// float16Bits converts d to the nearest IEEE 754 half-precision float,
+// rounding ties to even.
+func float16Bits(d float64) uint16 {
+	b := math.Float64bits(d)
+	sign := uint16(b>>48) & 0x8000
+	exp, mant := int(b>>52&0x7ff), b&(1<<52-1)
+	if exp == 0x7ff {
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+
+		return sign | 0x7c00
+	}
+
+	// round shifts m right, rounding to nearest even.
+	round := func(m uint64, shift uint) uint64 {
+		rem, half := m&(1<<shift-1), uint64(1)<<(shift-1)
+		m >>= shift
+		if rem > half || (rem == half && m&1 == 1) {
+			m++
+		}
+
+		return m
+	}
+
+	switch e := exp - 1023 + 15; {
+	case e >= 31:
+		return sign | 0x7c00
+	case e > 0:
+		// A carry out of the mantissa correctly bumps the exponent,
+		// overflowing to Inf at the top of the range.
+		return sign | uint16(round(uint64(e)<<52|mant, 42))
+	case e >= -10:
+		return sign | uint16(round(mant|1<<52, uint(43-e)))
+	default:
+		return sign
+	}
+}
+
+// float16Value converts IEEE 754 half-precision bits to a float64.
+func float16Value(h uint16) float64 {
+	sign := float64(1)
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+
+	switch exp, mant := int(h>>10&0x1f), float64(h&0x3ff); exp {
+	case 0:
+		return sign * math.Ldexp(mant, -24)
+	case 0x1f:
+		if mant != 0 {
+			return math.NaN()
+		}
+
+		return math.Inf(int(sign))
+	default:
+		return sign * math.Ldexp(1024+mant, exp-25)
+	}
+}
+
+// value returns the i'th stored value.
+func (q QuantizedVector) value(i int) float64 {
+	if q.precision == Int8 {
+		return float64(q.int8s[i]) * q.scale
+	}
+
+	return float16Value(q.float16s[i])
+}
+
+// Dequantize converts the QuantizedVector back to a Vector.
+func (q QuantizedVector) Dequantize() Vector {
+	ret := NewVector(q.dim, WithCapacity(len(q.indices)))
+	for i, n := range q.indices {
+		ret.data[n] = q.value(i)
+	}
+
+	return ret
+}
+
+// Size is the dimensionality of the vector.
+func (q QuantizedVector) Size() int {
+	return q.dim
+}
+
+// NNZ is the number of non-zero entries.
+func (q QuantizedVector) NNZ() int {
+	return len(q.indices)
+}
+
+// Precision is the storage format of the values.
+func (q QuantizedVector) Precision() Precision {
+	return q.precision
+}
+
+// Get data from the n'th dimension, by binary search.
+func (q QuantizedVector) Get(n int) float64 {
+	if i, ok := slices.BinarySearch(q.indices, n); ok {
+		return q.value(i)
+	}
+
+	return 0
+}
+
+// NonZeros returns an iterator over the index and value of each non-zero
+// entry, in ascending index order.
+func (q QuantizedVector) NonZeros() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i, n := range q.indices {
+			if !yield(n, q.value(i)) {
+				return
+			}
+		}
+	}
+}
+
+// DotVector is the dot product with a Vector. For Int8 it sums the
+// products with the raw quantized values and scales the total once.
+func (q QuantizedVector) DotVector(v Vector) float64 {
+	ret := float64(0)
+	if q.precision == Int8 {
+		for i, n := range q.indices {
+			ret += float64(q.int8s[i]) * v.data[n]
+		}
+
+		return ret * q.scale
+	}
+
+	for i, n := range q.indices {
+		ret += float16Value(q.float16s[i]) * v.data[n]
+	}
+
+	return ret
+}
+
+// Dot product with another QuantizedVector, in a single merge of their
+// indices. Between two Int8 vectors it is computed in exact integer
+// arithmetic and scaled once. Like Dot, it does not check that their
+// dimensions match.
+func (q QuantizedVector) Dot(other QuantizedVector) float64 {
+	exact := q.precision == Int8 && other.precision == Int8
+	sum, ret := int64(0), float64(0)
+	i, j := 0, 0
+	for i < len(q.indices) && j < len(other.indices) {
+		switch a, b := q.indices[i], other.indices[j]; {
+		case a < b:
+			i++
+		case a > b:
+			j++
+		default:
+			if exact {
+				sum += int64(q.int8s[i]) * int64(other.int8s[j])
+			} else {
+				ret += q.value(i) * other.value(j)
+			}
+
+			i++
+			j++
+		}
+	}
+
+	if exact {
+		return float64(sum) * q.scale * other.scale
+	}
+
+	return ret
+}
@@ -0,0 +1,80 @@
+package sparse
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// Distribution draws random values from r.
+type Distribution func(r *rand.Rand) float64
+
+// Gaussian draws values from the normal distribution with the given
+// mean and standard deviation.
+func Gaussian(mean float64, stddev float64) Distribution {
+	return func(r *rand.Rand) float64 {
+		return mean + stddev*r.NormFloat64()
+	}
+}
+
+// Uniform draws values uniformly from [lo, hi).
+func Uniform(lo float64, hi float64) Distribution {
+	return func(r *rand.Rand) float64 {
+		return lo + (hi-lo)*r.Float64()
+	}
+}
+
+// Rademacher draws 1 or -1 with equal probability, as in sparse random
+// projections.
+func Rademacher() Distribution {
+	return func(r *rand.Rand) float64 {
+		if r.Uint64()&1 == 0 {
+			return 1
+		}
+
+		return -1
+	}
+}
+
+// NewRand returns a random number generator seeded with seed, so that
+// Vectors generated from it are reproducible.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// Random generates a Vector with dim dimensions, nnz of which are chosen
+// uniformly at random and drawn from the standard normal distribution.
+// It panics unless nnz is in [0, dim].
+func Random(dim int, nnz int, r *rand.Rand) Vector {
+	return RandomFrom(dim, nnz, r, Gaussian(0, 1))
+}
+
+// RandomFrom generates a Vector like Random with values drawn from
+// dist. Draws of zero are not stored, leaving fewer than nnz entries.
+func RandomFrom(dim int, nnz int, r *rand.Rand, dist Distribution) Vector {
+	if nnz < 0 || nnz > dim {
+		panic(fmt.Sprintf("sparse: cannot choose %d of %d dimensions", nnz, dim))
+	}
+
+	// Floyd's algorithm picks nnz distinct indices with nnz draws. They
+	// are sorted before values are drawn, so that a seed always yields
+	// the same Vector.
+	chosen := make(map[int]bool, nnz)
+	for j := dim - nnz; j < dim; j++ {
+		if n := r.IntN(j + 1); chosen[n] {
+			chosen[j] = true
+		} else {
+			chosen[n] = true
+		}
+	}
+
+	ret := NewVector(dim, WithCapacity(nnz))
+	for _, n := range slices.Sorted(maps.Keys(chosen)) {
+		if d := dist(r); d != 0 {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
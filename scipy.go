@@ -0,0 +1,382 @@
+package sparse
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ReadScipyCSR reads a CSR matrix saved by scipy.sparse.save_npz into
+// a CSR Matrix. Its rows' indices may be in any order, and the values
+// of repeated entries are summed, as scipy does.
+func ReadScipyCSR(r io.Reader) (Matrix, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return Matrix{}, err
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf), int64(len(buf)))
+	if err != nil {
+		return Matrix{}, fmt.Errorf("sparse: npz: %w", err)
+	}
+
+	arrays := map[string]npyArray{}
+	for _, f := range zr.File {
+		name, ok := strings.CutSuffix(f.Name, ".npy")
+		if !ok {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return Matrix{}, fmt.Errorf("sparse: npz: %w", err)
+		}
+
+		arr, err := readNpy(rc)
+		rc.Close()
+		if err != nil {
+			return Matrix{}, fmt.Errorf("sparse: npz: %s: %w", f.Name, err)
+		}
+
+		arrays[name] = arr
+	}
+
+	for _, name := range []string{"format", "shape", "data", "indices", "indptr"} {
+		if _, ok := arrays[name]; !ok {
+			return Matrix{}, fmt.Errorf("sparse: npz: missing %s array", name)
+		}
+	}
+
+	if format := strings.TrimRight(string(arrays["format"].raw), "\x00"); format != "csr" {
+		return Matrix{}, fmt.Errorf("sparse: npz: unsupported format %q", format)
+	}
+
+	shape, err1 := arrays["shape"].ints()
+	data, err2 := arrays["data"].floats()
+	indices, err3 := arrays["indices"].ints()
+	indptr, err4 := arrays["indptr"].ints()
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return Matrix{}, fmt.Errorf("sparse: npz: %w", err)
+	} else if len(shape) != 2 || shape[0] < 0 || shape[1] < 0 || len(indptr) != shape[0]+1 ||
+		len(indices) != len(data) || indptr[0] != 0 || indptr[shape[0]] != len(data) {
+		return Matrix{}, errors.New("sparse: npz: inconsistent CSR arrays")
+	}
+
+	is := make([]int, len(data))
+	for i := range shape[0] {
+		lo, hi := indptr[i], indptr[i+1]
+		if lo > hi || hi > len(data) {
+			return Matrix{}, errors.New("sparse: npz: inconsistent CSR arrays")
+		}
+
+		for k := lo; k < hi; k++ {
+			is[k] = i
+		}
+	}
+
+	m, err := NewMatrixFromTriplets(shape[0], shape[1], is, indices, data, CSR)
+	if err != nil {
+		return Matrix{}, fmt.Errorf("sparse: npz: %w", err)
+	}
+
+	return m, nil
+}
+
+// WriteScipyCSR writes m as a CSR matrix in the layout of
+// scipy.sparse.save_npz, with float64 data and int32 indices when the
+// matrix is small enough for them (int64 otherwise).
+func WriteScipyCSR(w io.Writer, m Matrix) error {
+	m = m.ToCSR()
+	rows, cols := m.Dims()
+	data, indices, indptr := m.val, m.ind, m.ptr
+	if indptr == nil {
+		indptr = make([]int, rows+1) // the zero Matrix
+	}
+
+	idxType := "<i4"
+	if cols > math.MaxInt32 || len(indices) > math.MaxInt32 {
+		idxType = "<i8"
+	}
+
+	zw := zip.NewWriter(w)
+	arrays := []struct {
+		name  string
+		descr string
+		shape string
+		raw   []byte
+	}{
+		{"indices", idxType, tuple(len(indices)), encodeInts(idxType, indices)},
+		{"indptr", idxType, tuple(len(indptr)), encodeInts(idxType, indptr)},
+		{"format", "|S3", "()", []byte("csr")},
+		{"shape", "<i8", "(2,)", encodeInts("<i8", []int{rows, cols})},
+		{"data", "<f8", tuple(len(data)), encodeFloats(data)},
+	}
+
+	for _, arr := range arrays {
+		f, err := zw.Create(arr.name + ".npy")
+		if err != nil {
+			return err
+		}
+
+		if err := writeNpy(f, arr.descr, arr.shape, arr.raw); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// npyArray is a decoded .npy file: its dtype descriptor and raw
+// element bytes.
+type npyArray struct {
+	descr string
+	raw   []byte
+}
+
+var npyMagic = []byte("\x93NUMPY")
+
+func readNpy(r io.Reader) (npyArray, error) {
+	var arr npyArray
+	pre := make([]byte, 8)
+	if _, err := io.ReadFull(r, pre); err != nil {
+		return arr, err
+	} else if !bytes.Equal(pre[:6], npyMagic) {
+		return arr, errors.New("not a .npy file")
+	}
+
+	var hlen int
+	switch pre[6] {
+	case 1:
+		b := make([]byte, 2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return arr, err
+		}
+
+		hlen = int(binary.LittleEndian.Uint16(b))
+	case 2, 3:
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return arr, err
+		}
+
+		hlen = int(binary.LittleEndian.Uint32(b))
+	default:
+		return arr, fmt.Errorf("unsupported .npy version %d", pre[6])
+	}
+
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return arr, err
+	}
+
+	h := string(header)
+	if strings.Contains(h, "'fortran_order': True") {
+		return arr, errors.New("fortran-ordered arrays are not supported")
+	}
+
+	_, rest, ok := strings.Cut(h, "'descr':")
+	rest = strings.TrimSpace(rest)
+	if !ok || len(rest) < 2 {
+		return arr, errors.New("malformed .npy header")
+	}
+
+	quote := rest[:1]
+	arr.descr, _, ok = strings.Cut(rest[1:], quote)
+	if !ok {
+		return arr, errors.New("malformed .npy header")
+	}
+
+	_, _, size, err := byteOrder(arr.descr)
+	if err != nil {
+		return arr, err
+	}
+
+	count, err := npyCount(h)
+	if err != nil {
+		return arr, err
+	} else if count > math.MaxInt/size {
+		return arr, fmt.Errorf("shape of %d elements too large", count)
+	}
+
+	// Read no more than the header's shape gives, so that a compressed
+	// entry cannot inflate without limit.
+	arr.raw, err = io.ReadAll(io.LimitReader(r, int64(count*size)))
+	if err == nil && len(arr.raw) != count*size {
+		err = fmt.Errorf("%d bytes of data, want %d", len(arr.raw), count*size)
+	}
+
+	return arr, err
+}
+
+// npyCount is the number of elements in the shape of a .npy header.
+func npyCount(header string) (int, error) {
+	_, rest, ok := strings.Cut(header, "'shape':")
+	rest, ok2 := strings.CutPrefix(strings.TrimSpace(rest), "(")
+	shape, _, ok3 := strings.Cut(rest, ")")
+	if !ok || !ok2 || !ok3 {
+		return 0, errors.New("malformed .npy shape")
+	}
+
+	ret := 1
+	for _, field := range strings.Split(shape, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("malformed .npy shape %q", shape)
+		} else if n > 0 && ret > math.MaxInt/n {
+			return 0, fmt.Errorf(".npy shape %q too large", shape)
+		}
+
+		ret *= n
+	}
+
+	return ret, nil
+}
+
+func writeNpy(w io.Writer, descr, shape string, raw []byte) error {
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': False, 'shape': %s, }", descr, shape)
+
+	// Pad so the data starts on a 64-byte boundary, ending in a newline.
+	pad := 64 - (len(npyMagic)+4+len(header)+1)%64
+	header += strings.Repeat(" ", pad%64) + "\n"
+	pre := append(slices.Clip(npyMagic), 1, 0, 0, 0)
+	binary.LittleEndian.PutUint16(pre[8:], uint16(len(header)))
+	if _, err := w.Write(pre); err != nil {
+		return err
+	} else if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	_, err := w.Write(raw)
+	return err
+}
+
+// tuple formats a 1-D numpy shape.
+func tuple(n int) string {
+	return "(" + strconv.Itoa(n) + ",)"
+}
+
+// byteOrder resolves a dtype's byte order, kind and positive element
+// size, e.g. "<i4".
+func byteOrder(descr string) (binary.ByteOrder, byte, int, error) {
+	if len(descr) < 3 {
+		return nil, 0, 0, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	size, err := strconv.Atoi(descr[2:])
+	if err != nil || size <= 0 {
+		return nil, 0, 0, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	var order binary.ByteOrder = binary.LittleEndian
+	switch descr[0] {
+	case '<', '|', '=':
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, 0, 0, fmt.Errorf("unsupported dtype %q", descr)
+	}
+
+	return order, descr[1], size, nil
+}
+
+// ints decodes an integer array.
+func (arr npyArray) ints() ([]int, error) {
+	order, kind, size, err := byteOrder(arr.descr)
+	if err != nil {
+		return nil, err
+	} else if (kind != 'i' && kind != 'u') || (size != 1 && size != 2 && size != 4 && size != 8) || len(arr.raw)%size != 0 {
+		return nil, fmt.Errorf("unsupported index dtype %q", arr.descr)
+	}
+
+	ret := make([]int, len(arr.raw)/size)
+	for i := range ret {
+		b := arr.raw[i*size:]
+		switch {
+		case size == 1 && kind == 'i':
+			ret[i] = int(int8(b[0]))
+		case size == 1:
+			ret[i] = int(b[0])
+		case size == 2 && kind == 'i':
+			ret[i] = int(int16(order.Uint16(b)))
+		case size == 2:
+			ret[i] = int(order.Uint16(b))
+		case size == 4 && kind == 'i':
+			ret[i] = int(int32(order.Uint32(b)))
+		case size == 4:
+			ret[i] = int(order.Uint32(b))
+		case size == 8 && (kind == 'i' || order.Uint64(b) <= math.MaxInt64):
+			ret[i] = int(order.Uint64(b))
+		default:
+			return nil, fmt.Errorf("unsupported index dtype %q", arr.descr)
+		}
+	}
+
+	return ret, nil
+}
+
+// floats decodes a numeric array as float64.
+func (arr npyArray) floats() ([]float64, error) {
+	order, kind, size, err := byteOrder(arr.descr)
+	if err != nil {
+		return nil, err
+	} else if kind != 'f' {
+		ints, err := arr.ints()
+		ret := make([]float64, len(ints))
+		for i, n := range ints {
+			ret[i] = float64(n)
+		}
+
+		return ret, err
+	} else if (size != 4 && size != 8) || len(arr.raw)%size != 0 {
+		return nil, fmt.Errorf("unsupported data dtype %q", arr.descr)
+	}
+
+	ret := make([]float64, len(arr.raw)/size)
+	for i := range ret {
+		if size == 4 {
+			ret[i] = float64(math.Float32frombits(order.Uint32(arr.raw[i*4:])))
+		} else {
+			ret[i] = math.Float64frombits(order.Uint64(arr.raw[i*8:]))
+		}
+	}
+
+	return ret, nil
+}
+
+func encodeInts(descr string, xs []int) []byte {
+	if descr == "<i4" {
+		ret := make([]byte, 0, 4*len(xs))
+		for _, x := range xs {
+			ret = binary.LittleEndian.AppendUint32(ret, uint32(x))
+		}
+
+		return ret
+	}
+
+	ret := make([]byte, 0, 8*len(xs))
+	for _, x := range xs {
+		ret = binary.LittleEndian.AppendUint64(ret, uint64(x))
+	}
+
+	return ret
+}
+
+func encodeFloats(xs []float64) []byte {
+	ret := make([]byte, 0, 8*len(xs))
+	for _, x := range xs {
+		ret = binary.LittleEndian.AppendUint64(ret, math.Float64bits(x))
+	}
+
+	return ret
+}
@@ -0,0 +1,112 @@
+package sparse
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestScipyCSRRoundTrip(t *testing.T) {
+	m := NewMatrixFromRows([]Vector{
+		NewVectorFromArray([]float64{0, 1.5, 0}),
+		NewVector(3),
+		NewVectorFromArray([]float64{-2, 0, 4}),
+	})
+
+	var buf bytes.Buffer
+	if err := WriteScipyCSR(&buf, m.T().T()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadScipyCSR(&buf)
+	if err != nil {
+		t.Fatal(err)
+	} else if r, c := got.Dims(); r != 3 || c != 3 || got.NNZ() != 3 {
+		t.Fatalf("got %d×%d with %d entries, want 3×3 with 3", r, c, got.NNZ())
+	}
+
+	for i := range 3 {
+		if !Equal(got.Row(i), m.Row(i)) {
+			t.Errorf("row %d = %v, want %v", i, got.Row(i), m.Row(i))
+		}
+	}
+}
+
+func TestScipyCSRZeroMatrix(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteScipyCSR(&buf, Matrix{}); err != nil {
+		t.Fatal(err)
+	} else if got, err := ReadScipyCSR(&buf); err != nil {
+		t.Fatal(err)
+	} else if r, c := got.Dims(); r != 0 || c != 0 {
+		t.Errorf("got %d×%d, want 0×0", r, c)
+	}
+}
+
+// npz writes a .npz file of the given arrays, which are (name, descr,
+// shape, raw) quadruples.
+func npz(t *testing.T, arrays ...[4]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, arr := range arrays {
+		f, err := zw.Create(arr[0] + ".npy")
+		if err != nil {
+			t.Fatal(err)
+		} else if err := writeNpy(f, arr[1], arr[2], []byte(arr[3])); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestReadScipyCSRBadDtype(t *testing.T) {
+	for _, descr := range []string{"<i0", "<i-4", "<i3", "<i16"} {
+		b := npz(t,
+			[4]string{"format", "|S3", "()", "csr"},
+			[4]string{"shape", "<i8", "(2,)", string(encodeInts("<i8", []int{1, 1}))},
+			[4]string{"data", "<f8", "(0,)", ""},
+			[4]string{"indices", descr, "(0,)", ""},
+			[4]string{"indptr", "<i8", "(2,)", string(encodeInts("<i8", []int{0, 0}))},
+		)
+
+		if _, err := ReadScipyCSR(bytes.NewReader(b)); err == nil {
+			t.Errorf("%s: got no error", descr)
+		}
+	}
+}
+
+// zeros is an endless stream of zero bytes, like an inflating zip
+// bomb.
+type zeros struct{}
+
+func (zeros) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestReadNpyLimitsData(t *testing.T) {
+	var header bytes.Buffer
+	if err := writeNpy(&header, "<f8", "(2,)", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	arr, err := readNpy(io.MultiReader(&header, zeros{}))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(arr.raw) != 16 {
+		t.Errorf("read %d bytes, want the 16 the shape gives", len(arr.raw))
+	}
+
+	header.Reset()
+	writeNpy(&header, "<f8", "(2,)", make([]byte, 8))
+	if _, err := readNpy(&header); err == nil {
+		t.Error("got no error for truncated data")
+	}
+}
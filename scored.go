@@ -0,0 +1,137 @@
+package sparse
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ScoredVector is a snapshot of a Vector with the norm of each block of
+// its dimensions precomputed, so that DotAtLeast can bound the Dot
+// product of two of them by Cauchy-Schwarz and stop as soon as it
+// cannot reach a threshold. It suits filtering a large corpus for
+// candidates above a score.
+type ScoredVector struct {
+	blockSize int
+	norm      float64
+	blocks    map[int]scoredBlock
+}
+
+// scoredBlock is a block of a ScoredVector: its entries in ascending
+// index order, and their norm.
+type scoredBlock struct {
+	entries []Entry
+	norm    float64
+}
+
+// NewScoredVector snapshots v into blocks of blockSize dimensions. Small
+// blocks give tighter bounds at the cost of more of them. It panics
+// unless blockSize is positive.
+func NewScoredVector(v Vector, blockSize int) ScoredVector {
+	if blockSize <= 0 {
+		panic("sparse: scored vectors need a positive block size")
+	}
+
+	ret := ScoredVector{blockSize: blockSize, blocks: map[int]scoredBlock{}}
+	for n, d := range v.SortedNonZeros() {
+		b := ret.blocks[n/blockSize]
+		b.entries = append(b.entries, Entry{Index: n, Value: d})
+		b.norm += d * d
+		ret.blocks[n/blockSize] = b
+		ret.norm += d * d
+	}
+
+	for k, b := range ret.blocks {
+		b.norm = math.Sqrt(b.norm)
+		ret.blocks[k] = b
+	}
+
+	ret.norm = math.Sqrt(ret.norm)
+	return ret
+}
+
+// Magnitude (scalar) of the vector.
+func (s ScoredVector) Magnitude() float64 {
+	return s.norm
+}
+
+// dot is the Dot product of two blocks' entries.
+func (b scoredBlock) dot(other scoredBlock) float64 {
+	ret := float64(0)
+	i, j := 0, 0
+	for i < len(b.entries) && j < len(other.entries) {
+		switch x, y := b.entries[i], other.entries[j]; {
+		case x.Index < y.Index:
+			i++
+		case x.Index > y.Index:
+			j++
+		default:
+			ret += x.Value * y.Value
+			i++
+			j++
+		}
+	}
+
+	return ret
+}
+
+// DotAtLeast reports whether the Dot product of v1 and v2 is at least
+// threshold, returning it if so. It visits the blocks both store in
+// descending order of their bound, and gives up once the Dot product
+// of the blocks visited plus the bound on the rest falls below
+// threshold. It panics unless v1 and v2 have the same block size.
+func DotAtLeast(v1 ScoredVector, v2 ScoredVector, threshold float64) (float64, bool) {
+	if v1.blockSize != v2.blockSize {
+		panic(fmt.Errorf("%w: block sizes %d and %d", ErrDimensionMismatch, v1.blockSize, v2.blockSize))
+	}
+
+	if len(v2.blocks) < len(v1.blocks) {
+		v1, v2 = v2, v1
+	}
+
+	type pair struct {
+		b1, b2 scoredBlock
+		bound  float64
+	}
+
+	pairs := make([]pair, 0, len(v1.blocks))
+	for k, b1 := range v1.blocks {
+		if b2, ok := v2.blocks[k]; ok {
+			pairs = append(pairs, pair{b1: b1, b2: b2, bound: b1.norm * b2.norm})
+		}
+	}
+
+	slices.SortFunc(pairs, func(a pair, b pair) int { return cmp.Compare(b.bound, a.bound) })
+
+	// rest[i] bounds the blocks from the i'th on, summed from the
+	// smallest so that it is not degraded by repeated subtraction.
+	rest := make([]float64, len(pairs)+1)
+	for i := len(pairs) - 1; i >= 0; i-- {
+		rest[i] = rest[i+1] + pairs[i].bound
+	}
+
+	ret := float64(0)
+	for i, p := range pairs {
+		if ret+rest[i] < threshold {
+			return 0, false
+		}
+
+		ret += p.b1.dot(p.b2)
+	}
+
+	return ret, ret >= threshold
+}
+
+// SimilarityAtLeast reports whether the cosine similarity of v1 and v2
+// is at least threshold, returning it if so, by DotAtLeast. It is false
+// if either is a zero vector.
+func SimilarityAtLeast(v1 ScoredVector, v2 ScoredVector, threshold float64) (float64, bool) {
+	norms := v1.norm * v2.norm
+	if norms == 0 {
+		return 0, false
+	}
+
+	dot, ok := DotAtLeast(v1, v2, threshold*norms)
+	return max(-1, min(1, dot/norms)), ok
+}
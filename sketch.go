@@ -0,0 +1,123 @@
+package sparse
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SketchVector approximates a Vector of unbounded dimensionality in
+// fixed memory with a Count-Sketch: depth rows of width signed
+// counters, each index hashing to one counter per row. Estimates are
+// unbiased, with error proportional to the Vector's Magnitude divided
+// by √width, and taking the median over rows makes large errors
+// exponentially unlikely in depth.
+//
+// SketchVectors with the same width, depth and seed are linear, so they
+// can be merged to sketch the sum of their streams.
+type SketchVector struct {
+	width, depth int
+	seed         uint64
+	seeds        []uint64 // per-row hash seeds
+	table        []float64
+}
+
+// NewSketchVector constructs an empty SketchVector of depth rows of
+// width counters, hashing with seed. It panics unless width and depth
+// are positive.
+func NewSketchVector(width int, depth int, seed uint64) *SketchVector {
+	if width <= 0 || depth <= 0 {
+		panic("sparse: sketch needs positive width and depth")
+	}
+
+	s := &SketchVector{width: width, depth: depth, seed: seed, seeds: make([]uint64, depth), table: make([]float64, width*depth)}
+	for r := range s.seeds {
+		s.seeds[r] = splitmix(seed + uint64(r))
+	}
+
+	return s
+}
+
+// cell returns the position in the table of index n's counter in row r,
+// and the sign its values are multiplied by.
+func (s *SketchVector) cell(r int, n int) (int, float64) {
+	x := splitmix(s.seeds[r] ^ uint64(n))
+	sign := float64(1)
+	if x>>63 == 1 {
+		sign = -1
+	}
+
+	return r*s.width + int((x&^(1<<63))%uint64(s.width)), sign
+}
+
+// Add value to the n'th dimension.
+func (s *SketchVector) Add(n int, value float64) {
+	for r := range s.depth {
+		k, sign := s.cell(r, n)
+		s.table[k] += sign * value
+	}
+}
+
+// AddVector adds every entry of v.
+func (s *SketchVector) AddVector(v Vector) {
+	for n, d := range v.NonZeros() {
+		s.Add(n, d)
+	}
+}
+
+// Estimate returns an estimate of the value of the n'th dimension.
+func (s *SketchVector) Estimate(n int) float64 {
+	return s.median(func(r int) float64 {
+		k, sign := s.cell(r, n)
+		return sign * s.table[k]
+	})
+}
+
+// Dot estimates the dot product of the sketched Vector with v, in time
+// proportional to v's entries.
+func (s *SketchVector) Dot(v Vector) float64 {
+	return s.median(func(r int) float64 {
+		sum := float64(0)
+		for n, d := range v.NonZeros() {
+			k, sign := s.cell(r, n)
+			sum += d * sign * s.table[k]
+		}
+
+		return sum
+	})
+}
+
+// median returns the median over rows of row(r).
+func (s *SketchVector) median(row func(r int) float64) float64 {
+	xs := make([]float64, s.depth)
+	for r := range xs {
+		xs[r] = row(r)
+	}
+
+	slices.Sort(xs)
+	if s.depth%2 == 1 {
+		return xs[s.depth/2]
+	}
+
+	return (xs[s.depth/2-1] + xs[s.depth/2]) / 2
+}
+
+// Merge adds the counters of other, so the SketchVector sketches the
+// sum of both streams. It returns ErrDimensionMismatch unless the two
+// have the same width, depth and seed.
+func (s *SketchVector) Merge(other *SketchVector) error {
+	if s.width != other.width || s.depth != other.depth || s.seed != other.seed {
+		return fmt.Errorf("%w: cannot merge %d×%d sketch seeded %d into %d×%d sketch seeded %d",
+			ErrDimensionMismatch, other.depth, other.width, other.seed, s.depth, s.width, s.seed)
+	}
+
+	for k, d := range other.table {
+		s.table[k] += d
+	}
+
+	return nil
+}
+
+// Reset clears the SketchVector's counters.
+func (s *SketchVector) Reset() {
+	clear(s.table)
+}
@@ -0,0 +1,106 @@
+package sparse
+
+import "fmt"
+
+// Slice extracts dimensions [from, to) of the Vector into a new Vector
+// of to-from dimensions, shifting their indices down by from. It panics
+// unless 0 <= from <= to <= Size().
+func (v Vector) Slice(from int, to int) Vector {
+	if from < 0 || to < from || to > v.dim {
+		panic(fmt.Errorf("%w: slice [%d:%d] of dim %d", ErrOutOfRange, from, to, v.dim))
+	}
+
+	ret := NewVector(to - from)
+	if to-from < len(v.data) {
+		for n := from; n < to; n++ {
+			if d, ok := v.data[n]; ok {
+				ret.data[n-from] = d
+			}
+		}
+	} else {
+		for n, d := range v.data {
+			if n >= from && n < to {
+				ret.data[n-from] = d
+			}
+		}
+	}
+
+	return ret
+}
+
+// Select gathers the given dimensions of the Vector into a new Vector,
+// whose i'th dimension is the Vector's indices[i]'th. Indices may
+// repeat, and those outside the Vector read as zero.
+func (v Vector) Select(indices []int) Vector {
+	ret := NewVector(len(indices))
+	for i, n := range indices {
+		if d, ok := v.data[n]; ok && n >= 0 && n < v.dim {
+			ret.data[i] = d
+		}
+	}
+
+	return ret
+}
+
+// Split cuts the Vector into consecutive blocks of the given
+// dimensionalities, undoing Append. It panics if they sum to more than
+// the Vector's dimensionality; any remainder is left out.
+func (v Vector) Split(dims ...int) []Vector {
+	ret := make([]Vector, len(dims))
+	from := 0
+	for i, dim := range dims {
+		ret[i] = v.Slice(from, from+dim)
+		from += dim
+	}
+
+	return ret
+}
+
+// Permute moves each dimension n of the Vector to dimension perm[n],
+// returning a new Vector of the same dimensionality. It panics unless
+// perm is a permutation of [0, Size()).
+func (v Vector) Permute(perm []int) Vector {
+	if len(perm) != v.dim {
+		panic(fmt.Errorf("%w: permutation of %d for dim %d", ErrDimensionMismatch, len(perm), v.dim))
+	}
+
+	seen := make([]bool, v.dim)
+	for _, m := range perm {
+		if m < 0 || m >= v.dim || seen[m] {
+			panic(fmt.Errorf("%w: %v is not a permutation of [0, %d)", ErrOutOfRange, perm, v.dim))
+		}
+
+		seen[m] = true
+	}
+
+	ret := NewVector(v.dim, WithCapacity(len(v.data)))
+	for n, d := range v.data {
+		if n >= 0 && n < v.dim {
+			ret.data[perm[n]] = d
+		}
+	}
+
+	return ret
+}
+
+// Remap moves each dimension n of the Vector to dimension mapping[n],
+// as when translating between vocabularies. Dimensions missing from
+// mapping are dropped if dropUnmapped is set, and otherwise kept where
+// they are. Values moved onto the same dimension are summed. The result
+// keeps the Vector's dimensionality, grown to fit the mapped indices.
+func (v Vector) Remap(mapping map[int]int, dropUnmapped bool) Vector {
+	ret := NewVector(v.dim, WithCapacity(len(v.data)))
+	for n, d := range v.data {
+		m, ok := mapping[n]
+		if !ok && dropUnmapped {
+			continue
+		} else if !ok {
+			m = n
+		}
+
+		ret.data[m] += d
+		ret.dim = max(ret.dim, m+1)
+	}
+
+	return ret
+}
@@ -0,0 +1,59 @@
+// Package s3blob adapts an Amazon S3 bucket to sparseblob.Bucket.
+package s3blob
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/angadn/sparse/sparseblob"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Bucket is a sparseblob.Bucket backed by an S3 bucket.
+type Bucket struct {
+	client *s3.Client
+	name   string
+}
+
+var _ sparseblob.Bucket = (*Bucket)(nil)
+
+// New constructs a Bucket for the S3 bucket called name.
+func New(client *s3.Client, name string) *Bucket {
+	return &Bucket{client: client, name: name}
+}
+
+// Put uploads an object.
+func (b *Bucket) Put(ctx context.Context, key string, data []byte) error {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(b.name),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(data),
+		ContentLength: aws.Int64(int64(len(data))),
+	})
+	return err
+}
+
+// GetRange downloads part of an object with an HTTP range request.
+func (b *Bucket) GetRange(ctx context.Context, key string, off, n int64) ([]byte, error) {
+	rng := fmt.Sprintf("bytes=%d-", off)
+	if n == 0 {
+		return []byte{}, nil
+	} else if n > 0 {
+		rng = fmt.Sprintf("bytes=%d-%d", off, off+n-1)
+	}
+
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(key),
+		Range:  aws.String(rng),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
@@ -0,0 +1,277 @@
+// Package sparseblob persists collections of sparse Vectors to object
+// storage as chunked snapshots that support partial, ranged loads.
+//
+// A snapshot under a prefix is a set of chunk objects, <prefix>chunk-N,
+// each a concatenation of binary-encoded vectors, plus an index object,
+// <prefix>index.json, recording where every vector lives. Readers fetch
+// the index once and then read single vectors or whole chunks with
+// ranged requests.
+package sparseblob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/angadn/sparse"
+)
+
+// Bucket is the minimal object store a snapshot needs.
+type Bucket interface {
+	// Put writes an object.
+	Put(ctx context.Context, key string, data []byte) error
+
+	// GetRange reads n bytes of an object starting at off. A negative n
+	// reads to the end of the object.
+	GetRange(ctx context.Context, key string, off, n int64) ([]byte, error)
+}
+
+// DefaultChunkSize is the target size of a chunk object in bytes.
+const DefaultChunkSize = 64 << 20
+
+// entry locates one vector within a chunk.
+type entry struct {
+	ID     string `json:"id"`
+	Chunk  int    `json:"chunk"`
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+}
+
+// within reports whether the entry lies inside a chunk of size bytes,
+// as a crafted index may place it anywhere.
+func (e entry) within(size int64) bool {
+	return e.Offset >= 0 && e.Length >= 0 && e.Offset <= size-e.Length
+}
+
+// index is the contents of a snapshot's index object.
+type index struct {
+	Chunks  []int64 `json:"chunks"`
+	Entries []entry `json:"entries"`
+}
+
+func chunkKey(prefix string, i int) string {
+	return fmt.Sprintf("%schunk-%06d", prefix, i)
+}
+
+func indexKey(prefix string) string {
+	return prefix + "index.json"
+}
+
+// Save writes every vector in store to bucket as a snapshot under
+// prefix, starting a new chunk once one reaches chunkSize bytes. A
+// chunkSize <= 0 uses DefaultChunkSize.
+func Save(ctx context.Context, bucket Bucket, prefix string, store sparse.Store, chunkSize int64) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	var (
+		idx  index
+		buf  bytes.Buffer
+		werr error
+	)
+
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+
+		if err := bucket.Put(ctx, chunkKey(prefix, len(idx.Chunks)), buf.Bytes()); err != nil {
+			return err
+		}
+
+		idx.Chunks = append(idx.Chunks, int64(buf.Len()))
+		buf.Reset()
+		return nil
+	}
+
+	err := store.Scan(ctx, func(id string, v sparse.Vector) bool {
+		data, err := v.MarshalBinary()
+		if err != nil {
+			werr = err
+			return false
+		}
+
+		idx.Entries = append(idx.Entries, entry{
+			ID:     id,
+			Chunk:  len(idx.Chunks),
+			Offset: int64(buf.Len()),
+			Length: int64(len(data)),
+		})
+
+		buf.Write(data)
+		if int64(buf.Len()) >= chunkSize {
+			werr = flush()
+		}
+
+		return werr == nil
+	})
+	if err != nil {
+		return err
+	} else if werr != nil {
+		return werr
+	} else if err := flush(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+
+	// The index goes last, so a snapshot is only visible once complete.
+	return bucket.Put(ctx, indexKey(prefix), data)
+}
+
+// Snapshot reads a snapshot written by Save.
+type Snapshot struct {
+	bucket Bucket
+	prefix string
+	index  index
+	byID   map[string]int
+}
+
+// Open reads the index of the snapshot under prefix.
+func Open(ctx context.Context, bucket Bucket, prefix string) (*Snapshot, error) {
+	data, err := bucket.GetRange(ctx, indexKey(prefix), 0, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{bucket: bucket, prefix: prefix, byID: map[string]int{}}
+	if err := json.Unmarshal(data, &s.index); err != nil {
+		return nil, fmt.Errorf("sparseblob: %s: %w", indexKey(prefix), err)
+	}
+
+	for i, e := range s.index.Entries {
+		s.byID[e.ID] = i
+	}
+
+	return s, nil
+}
+
+// Len is the number of vectors in the snapshot.
+func (s *Snapshot) Len() int {
+	return len(s.index.Entries)
+}
+
+// Chunks is the number of chunk objects in the snapshot.
+func (s *Snapshot) Chunks() int {
+	return len(s.index.Chunks)
+}
+
+// Get reads the vector stored under id with a single ranged read, or
+// returns sparse.ErrNotFound.
+func (s *Snapshot) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	i, ok := s.byID[id]
+	if !ok {
+		return sparse.Vector{}, sparse.ErrNotFound
+	}
+
+	e := s.index.Entries[i]
+	if e.Chunk < 0 || e.Chunk >= len(s.index.Chunks) || !e.within(s.index.Chunks[e.Chunk]) {
+		return sparse.Vector{}, fmt.Errorf("sparseblob: entry %q out of range of chunk %d", e.ID, e.Chunk)
+	}
+
+	data, err := s.bucket.GetRange(ctx, chunkKey(s.prefix, e.Chunk), e.Offset, e.Length)
+	if err != nil {
+		return sparse.Vector{}, err
+	}
+
+	var v sparse.Vector
+	err = v.UnmarshalBinary(data)
+	return v, err
+}
+
+// LoadChunk reads chunk i whole, calling fn for each of its vectors.
+func (s *Snapshot) LoadChunk(ctx context.Context, i int, fn func(id string, v sparse.Vector) bool) error {
+	if i < 0 || i >= len(s.index.Chunks) || s.index.Chunks[i] < 0 {
+		return fmt.Errorf("sparseblob: chunk %d out of range", i)
+	}
+
+	data, err := s.bucket.GetRange(ctx, chunkKey(s.prefix, i), 0, s.index.Chunks[i])
+	if err != nil {
+		return err
+	}
+
+	// Save writes entries in chunk order.
+	start := sort.Search(len(s.index.Entries), func(j int) bool {
+		return s.index.Entries[j].Chunk >= i
+	})
+
+	for _, e := range s.index.Entries[start:] {
+		if e.Chunk != i {
+			break
+		} else if !e.within(int64(len(data))) {
+			return fmt.Errorf("sparseblob: entry %q out of range of chunk %d", e.ID, i)
+		}
+
+		var v sparse.Vector
+		if err := v.UnmarshalBinary(data[e.Offset : e.Offset+e.Length]); err != nil {
+			return fmt.Errorf("sparseblob: entry %q: %w", e.ID, err)
+		}
+
+		if !fn(e.ID, v) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Restore loads every vector in the snapshot into store.
+func (s *Snapshot) Restore(ctx context.Context, store sparse.Store) error {
+	for i := range s.index.Chunks {
+		var err error
+		lerr := s.LoadChunk(ctx, i, func(id string, v sparse.Vector) bool {
+			err = store.Upsert(ctx, id, v)
+			return err == nil
+		})
+		if lerr != nil {
+			return lerr
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Dir is a Bucket of files in a local directory.
+type Dir string
+
+// Put writes the file dir/key.
+func (d Dir) Put(ctx context.Context, key string, data []byte) error {
+	path := filepath.Join(string(d), filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// GetRange reads part of the file dir/key.
+func (d Dir) GetRange(ctx context.Context, key string, off, n int64) ([]byte, error) {
+	f, err := os.Open(filepath.Join(string(d), filepath.FromSlash(key)))
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	if n < 0 {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+
+		n = max(info.Size()-off, 0)
+	}
+
+	buf := make([]byte, n)
+	_, err = f.ReadAt(buf, off)
+	return buf, err
+}
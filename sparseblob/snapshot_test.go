@@ -0,0 +1,86 @@
+package sparseblob
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+// save snapshots two vectors into a new Dir.
+func save(t *testing.T) Dir {
+	t.Helper()
+	ctx := context.Background()
+	store := sparse.NewMemoryStore()
+	store.Upsert(ctx, "a", sparse.NewVectorFromArray([]float64{1, 0, 2}))
+	store.Upsert(ctx, "b", sparse.NewVectorFromArray([]float64{0, -3}))
+
+	dir := Dir(t.TempDir())
+	if err := Save(ctx, dir, "snap/", store, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	s, err := Open(ctx, save(t), "snap/")
+	if err != nil {
+		t.Fatal(err)
+	} else if s.Len() != 2 || s.Chunks() != 1 {
+		t.Fatalf("got %d vectors in %d chunks, want 2 in 1", s.Len(), s.Chunks())
+	}
+
+	if v, err := s.Get(ctx, "b"); err != nil || v.Get(1) != -3 {
+		t.Errorf("Get(b) = %v, %v", v, err)
+	}
+
+	restored := sparse.NewMemoryStore()
+	if err := s.Restore(ctx, restored); err != nil {
+		t.Fatal(err)
+	} else if n, _ := restored.Len(ctx); n != 2 {
+		t.Errorf("restored %d vectors, want 2", n)
+	}
+}
+
+func TestSnapshotCraftedIndex(t *testing.T) {
+	ctx := context.Background()
+	for _, e := range []entry{
+		{ID: "x", Offset: 0, Length: -1},
+		{ID: "x", Offset: -4, Length: 4},
+		{ID: "x", Offset: 1 << 62, Length: 1 << 62},
+	} {
+		dir := save(t)
+		path := filepath.Join(string(dir), "snap", "index.json")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var idx index
+		if err := json.Unmarshal(data, &idx); err != nil {
+			t.Fatal(err)
+		}
+
+		idx.Entries = append([]entry{e}, idx.Entries...)
+		data, _ = json.Marshal(idx)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		s, err := Open(ctx, dir, "snap/")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := s.LoadChunk(ctx, 0, func(string, sparse.Vector) bool { return true }); err == nil {
+			t.Errorf("LoadChunk with entry %+v: got no error", e)
+		} else if _, err := s.Get(ctx, "x"); err == nil {
+			t.Errorf("Get with entry %+v: got no error", e)
+		}
+	}
+}
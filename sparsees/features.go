@@ -0,0 +1,59 @@
+// Package sparsees converts sparse Vectors to and from the payloads of
+// Elasticsearch and OpenSearch sparse_vector and rank_features fields,
+// which are JSON objects mapping feature names to positive weights.
+package sparsees
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/angadn/sparse"
+)
+
+// Features converts v into a field payload, naming each non-zero
+// dimension with name. A nil name uses the decimal index. Both field
+// types reject negative and NaN weights, so these are reported as
+// errors.
+func Features(v sparse.Vector, name func(n int) string) (map[string]float64, error) {
+	if name == nil {
+		name = strconv.Itoa
+	}
+
+	ret := map[string]float64{}
+	for n, d := range v.NonZeros() {
+		if !(d > 0) {
+			return nil, fmt.Errorf("sparsees: dimension %d has non-positive weight %g", n, d)
+		}
+
+		ret[name(n)] = d
+	}
+
+	return ret, nil
+}
+
+// FromFeatures converts a field payload into a Vector of dim
+// dimensions, looking up each feature's dimension with index. A nil
+// index parses decimal feature names. Features index does not know of,
+// or that fall outside dim, are reported as errors.
+func FromFeatures(dim int, features map[string]float64, index func(name string) (int, bool)) (sparse.Vector, error) {
+	if index == nil {
+		index = func(name string) (int, bool) {
+			n, err := strconv.Atoi(name)
+			return n, err == nil
+		}
+	}
+
+	ret := sparse.NewVector(dim)
+	for name, d := range features {
+		n, ok := index(name)
+		if !ok {
+			return sparse.Vector{}, fmt.Errorf("sparsees: unknown feature %q", name)
+		} else if n < 0 || n >= dim {
+			return sparse.Vector{}, fmt.Errorf("sparsees: feature %q index %d out of range for dim %d", name, n, dim)
+		}
+
+		ret.Set(n, d)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,33 @@
+package sparsees
+
+import (
+	"math"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+func TestFeaturesRejectsWeights(t *testing.T) {
+	for _, d := range []float64{-1, math.NaN()} {
+		v := sparse.NewVector(3)
+		v.Set(1, d)
+		if _, err := Features(v, nil); err == nil {
+			t.Errorf("Features() with weight %g: want error", d)
+		}
+	}
+}
+
+func TestFeaturesRoundTrip(t *testing.T) {
+	v := sparse.NewVectorFromArray([]float64{0, 2.5, 0, 1})
+	features, err := Features(v, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromFeatures(4, features, nil)
+	if err != nil {
+		t.Fatal(err)
+	} else if !sparse.Equal(got, v) {
+		t.Fatalf("FromFeatures(Features(v)) = %v, want %v", got, v)
+	}
+}
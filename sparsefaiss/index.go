@@ -0,0 +1,256 @@
+// Package sparsefaiss reads and writes Faiss flat indexes, optionally
+// wrapped in an IDMap, so vectors can be handed between Faiss and Go.
+//
+// Faiss stores flat indexes densely as float32, so vectors written
+// here lose precision and files read back are sparsified by dropping
+// zero components.
+package sparsefaiss
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/angadn/sparse"
+)
+
+// Metric is a Faiss metric type.
+type Metric int32
+
+const (
+	InnerProduct Metric = 0
+	L2           Metric = 1
+)
+
+// Index is the contents of a Faiss flat index.
+type Index struct {
+	// Metric is the index's metric.
+	Metric Metric
+
+	// IDs are the vector ids of an IDMap index, or nil for a bare flat
+	// index, whose ids are positions in Vectors.
+	IDs []int64
+
+	// Vectors are the indexed vectors, which all share one dimension.
+	Vectors []sparse.Vector
+}
+
+// Faiss fourcc codes, as stored little-endian on disk.
+const (
+	fourccFlatIP = "IxFI"
+	fourccFlatL2 = "IxF2"
+	fourccIDMap  = "IxMp"
+	fourccIDMap2 = "IxM2"
+)
+
+// header is Faiss' common index header.
+type header struct {
+	D         int32
+	NTotal    int64
+	Dummy1    int64
+	Dummy2    int64
+	IsTrained uint8
+	Metric    Metric
+}
+
+var errFormat = errors.New("sparsefaiss: unsupported index format")
+
+// Read reads an IndexFlatIP or IndexFlatL2, optionally wrapped in an
+// IndexIDMap or IndexIDMap2. If r reports its length, as a
+// bytes.Reader does, an index claiming more vectors than it holds is
+// rejected before anything is allocated for them.
+func Read(r io.Reader) (*Index, error) {
+	size := int64(-1)
+	if l, ok := r.(interface{ Len() int }); ok {
+		size = int64(l.Len())
+	}
+
+	br := bufio.NewReader(r)
+	code, h, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &Index{Metric: h.Metric}
+	idmap := code == fourccIDMap || code == fourccIDMap2
+	if idmap {
+		if code, h, err = readHeader(br); err != nil {
+			return nil, err
+		}
+	}
+
+	if code != fourccFlatIP && code != fourccFlatL2 {
+		return nil, errFormat
+	}
+
+	if idx.Vectors, err = readFlat(br, h, size); err != nil {
+		return nil, err
+	}
+
+	if idmap {
+		if idx.IDs, err = readIDs(br, h.NTotal); err != nil {
+			return nil, err
+		}
+	}
+
+	return idx, nil
+}
+
+func readHeader(r io.Reader) (string, header, error) {
+	var (
+		code [4]byte
+		h    header
+	)
+
+	if _, err := io.ReadFull(r, code[:]); err != nil {
+		return "", h, err
+	}
+
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		return "", h, err
+	} else if h.D < 0 || h.NTotal < 0 {
+		return "", h, errFormat
+	} else if h.Metric != InnerProduct && h.Metric != L2 {
+		// Other metrics carry an extra float argument we do not support.
+		return "", h, errFormat
+	}
+
+	return string(code[:]), h, nil
+}
+
+// chunk is how many values readFlat and readIDs decode at a time, so
+// that a corrupt header cannot make them allocate more than the input
+// holds.
+const chunk = 4096
+
+// readFlat reads the codes of a flat index, which size bytes of input
+// must be able to hold unless size is negative.
+func readFlat(r io.Reader, h header, size int64) ([]sparse.Vector, error) {
+	var n uint64
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	} else if h.D == 0 && h.NTotal > 0 {
+		return nil, fmt.Errorf("sparsefaiss: %d vectors of dim 0", h.NTotal)
+	} else if h.D > 0 && uint64(h.NTotal) > math.MaxUint64/uint64(h.D) {
+		return nil, fmt.Errorf("sparsefaiss: %d vectors of dim %d overflow", h.NTotal, h.D)
+	} else if n != uint64(h.NTotal)*uint64(h.D) {
+		return nil, fmt.Errorf("sparsefaiss: %d codes for %d vectors of dim %d", n, h.NTotal, h.D)
+	} else if size >= 0 && n > uint64(size)/4 {
+		return nil, fmt.Errorf("sparsefaiss: %d codes in %d bytes of input", n, size)
+	}
+
+	ret := make([]sparse.Vector, 0, min(h.NTotal, chunk))
+	row := make([]float32, min(int(h.D), chunk))
+	for range h.NTotal {
+		v := sparse.NewVector(int(h.D))
+		for j := 0; j < int(h.D); j += len(row) {
+			buf := row[:min(len(row), int(h.D)-j)]
+			if err := binary.Read(r, binary.LittleEndian, buf); err != nil {
+				return nil, err
+			}
+
+			for k, f := range buf {
+				if f != 0 {
+					v.Set(j+k, float64(f))
+				}
+			}
+		}
+
+		ret = append(ret, v)
+	}
+
+	return ret, nil
+}
+
+func readIDs(r io.Reader, ntotal int64) ([]int64, error) {
+	var n uint64
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, err
+	} else if n != uint64(ntotal) {
+		return nil, fmt.Errorf("sparsefaiss: %d ids for %d vectors", n, ntotal)
+	}
+
+	ids := make([]int64, 0, min(ntotal, chunk))
+	buf := make([]int64, min(ntotal, chunk))
+	for remaining := ntotal; remaining > 0; remaining -= int64(len(buf)) {
+		buf = buf[:min(int64(len(buf)), remaining)]
+		if err := binary.Read(r, binary.LittleEndian, buf); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, buf...)
+	}
+
+	return ids, nil
+}
+
+// Write writes idx as an IndexFlatIP or IndexFlatL2, wrapped in an
+// IndexIDMap if idx has IDs.
+func Write(w io.Writer, idx *Index) error {
+	d := 0
+	if len(idx.Vectors) > 0 {
+		d = idx.Vectors[0].Size()
+	}
+
+	for i, v := range idx.Vectors {
+		if v.Size() != d {
+			return fmt.Errorf("sparsefaiss: vector %d has dim %d, want %d", i, v.Size(), d)
+		}
+	}
+
+	if d > math.MaxInt32 {
+		return fmt.Errorf("sparsefaiss: dim %d too large", d)
+	} else if idx.IDs != nil && len(idx.IDs) != len(idx.Vectors) {
+		return fmt.Errorf("sparsefaiss: %d ids for %d vectors", len(idx.IDs), len(idx.Vectors))
+	}
+
+	var code string
+	switch idx.Metric {
+	case InnerProduct:
+		code = fourccFlatIP
+	case L2:
+		code = fourccFlatL2
+	default:
+		return errFormat
+	}
+
+	bw := bufio.NewWriter(w)
+	h := header{
+		D:         int32(d),
+		NTotal:    int64(len(idx.Vectors)),
+		Dummy1:    1 << 20,
+		Dummy2:    1 << 20,
+		IsTrained: 1,
+		Metric:    idx.Metric,
+	}
+
+	if idx.IDs != nil {
+		bw.WriteString(fourccIDMap)
+		binary.Write(bw, binary.LittleEndian, h)
+	}
+
+	bw.WriteString(code)
+	binary.Write(bw, binary.LittleEndian, h)
+	binary.Write(bw, binary.LittleEndian, uint64(len(idx.Vectors)*d))
+	row := make([]float32, d)
+	for _, v := range idx.Vectors {
+		clear(row)
+		for j, f := range v.NonZeros() {
+			if j >= 0 && j < d {
+				row[j] = float32(f)
+			}
+		}
+
+		binary.Write(bw, binary.LittleEndian, row)
+	}
+
+	if idx.IDs != nil {
+		binary.Write(bw, binary.LittleEndian, uint64(len(idx.IDs)))
+		binary.Write(bw, binary.LittleEndian, idx.IDs)
+	}
+
+	return bw.Flush()
+}
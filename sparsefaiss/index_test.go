@@ -0,0 +1,61 @@
+package sparsefaiss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+func TestRoundTrip(t *testing.T) {
+	idx := &Index{
+		Metric:  L2,
+		IDs:     []int64{7, 9},
+		Vectors: []sparse.Vector{sparse.NewVectorFromArray([]float64{1, 0, 2}), sparse.NewVectorFromArray([]float64{0, -3, 0})},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, idx); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	} else if got.Metric != L2 || !slices.Equal(got.IDs, idx.IDs) || len(got.Vectors) != 2 {
+		t.Fatalf("Read() = %+v", got)
+	}
+
+	for i, v := range got.Vectors {
+		if !sparse.Equal(v, idx.Vectors[i]) {
+			t.Errorf("vector %d = %v, want %v", i, v, idx.Vectors[i])
+		}
+	}
+}
+
+func TestReadCorruptHeader(t *testing.T) {
+	for _, tt := range []struct {
+		d      int32
+		ntotal int64
+		codes  uint64
+		metric Metric
+	}{
+		{d: math.MaxInt32, ntotal: math.MaxInt64, codes: 0},
+		{d: 1 << 20, ntotal: 1 << 40, codes: 1 << 60},
+		{d: 0, ntotal: 1 << 40, codes: 0},
+		{d: 1 << 10, ntotal: 1 << 20, codes: 1 << 30},
+		{d: 1, ntotal: 0, codes: 0, metric: -1},
+		{d: 1, ntotal: 0, codes: 0, metric: 7},
+	} {
+		var buf bytes.Buffer
+		buf.WriteString(fourccFlatIP)
+		binary.Write(&buf, binary.LittleEndian, header{D: tt.d, NTotal: tt.ntotal, Metric: tt.metric})
+		binary.Write(&buf, binary.LittleEndian, tt.codes)
+		if _, err := Read(&buf); err == nil {
+			t.Errorf("Read() of %d vectors of dim %d with %d codes and metric %d: want error", tt.ntotal, tt.d, tt.codes, tt.metric)
+		}
+	}
+}
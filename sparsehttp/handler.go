@@ -0,0 +1,235 @@
+// Package sparsehttp exposes sparse vector operations and a sparse.Store
+// as JSON over HTTP.
+//
+// Vectors are encoded as by sparse.Vector.MarshalJSON, as
+// {"dim": N, "data": {"3": 1.5, ...}}. The Handler serves:
+//
+//	PUT    /vectors/{id}   store the request body under id
+//	GET    /vectors/{id}   fetch a vector
+//	DELETE /vectors/{id}   delete a vector
+//	POST   /search         {"vector": ..., "k": 10} -> top-k matches
+//	POST   /similarity     {"a": ..., "b": ...} -> cosine similarity
+//	POST   /distance       {"a": ..., "b": ...} -> euclidean distance
+//
+// A search's k must be positive and at most DefaultMaxK, or the limit
+// given by WithMaxK. Request bodies larger than DefaultMaxBodyBytes, or
+// the limit given by WithMaxBodyBytes, are rejected with 413 Request
+// Entity Too Large.
+//
+// Mount it under a prefix with http.StripPrefix.
+package sparsehttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/angadn/sparse"
+)
+
+type searchRequest struct {
+	Vector sparse.Vector `json:"vector"`
+	K      int           `json:"k"`
+}
+
+type match struct {
+	ID    string  `json:"id"`
+	Score float64 `json:"score"`
+}
+
+type searchResponse struct {
+	Matches []match `json:"matches"`
+}
+
+type pairRequest struct {
+	A sparse.Vector `json:"a"`
+	B sparse.Vector `json:"b"`
+}
+
+type similarityResponse struct {
+	Similarity float64 `json:"similarity"`
+}
+
+type distanceResponse struct {
+	Distance float64 `json:"distance"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// DefaultMaxK is the largest k a search may ask for, unless configured
+// otherwise with WithMaxK.
+const DefaultMaxK = 1000
+
+// DefaultMaxBodyBytes is the largest request body the Handler reads,
+// unless configured otherwise with WithMaxBodyBytes.
+const DefaultMaxBodyBytes = 32 << 20
+
+var errUndefined = errors.New("sparsehttp: similarity is undefined for zero vectors and NaNs")
+
+// Handler serves the JSON API over a sparse.Store.
+type Handler struct {
+	store   sparse.Store
+	mux     *http.ServeMux
+	maxK    int
+	maxBody int64
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithMaxK limits searches to at most k matches. Searches asking for
+// more are rejected with 400 Bad Request.
+func WithMaxK(k int) Option {
+	return func(h *Handler) {
+		h.maxK = k
+	}
+}
+
+// WithMaxBodyBytes limits request bodies to at most n bytes.
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) {
+		h.maxBody = n
+	}
+}
+
+// NewHandler constructs a Handler backed by store.
+func NewHandler(store sparse.Store, opts ...Option) *Handler {
+	h := &Handler{store: store, mux: http.NewServeMux(), maxK: DefaultMaxK, maxBody: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("PUT /vectors/{id}", h.putVector)
+	h.mux.HandleFunc("GET /vectors/{id}", h.getVector)
+	h.mux.HandleFunc("DELETE /vectors/{id}", h.deleteVector)
+	h.mux.HandleFunc("POST /search", h.search)
+	h.mux.HandleFunc("POST /similarity", h.similarity)
+	h.mux.HandleFunc("POST /distance", h.distance)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) putVector(w http.ResponseWriter, r *http.Request) {
+	var v sparse.Vector
+	if !h.decode(w, r, &v) {
+		return
+	}
+
+	if err := h.store.Upsert(r.Context(), r.PathValue("id"), v); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) getVector(w http.ResponseWriter, r *http.Request) {
+	v, err := h.store.Get(r.Context(), r.PathValue("id"))
+	if errors.Is(err, sparse.ErrNotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	} else if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (h *Handler) deleteVector(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.Delete(r.Context(), r.PathValue("id")); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	var req searchRequest
+	if !h.decode(w, r, &req) {
+		return
+	} else if req.K <= 0 || req.K > h.maxK {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("sparsehttp: k must be in [1, %d], got %d", h.maxK, req.K))
+		return
+	}
+
+	matches, err := sparse.Query(r.Context(), h.store, req.Vector, req.K)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := searchResponse{Matches: make([]match, len(matches))}
+	for i, m := range matches {
+		res.Matches[i] = match{ID: m.ID, Score: m.Score}
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (h *Handler) similarity(w http.ResponseWriter, r *http.Request) {
+	var req pairRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	sim := sparse.Similarity(req.A, req.B)
+	if math.IsNaN(sim) {
+		writeError(w, http.StatusBadRequest, errUndefined)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, similarityResponse{Similarity: sim})
+}
+
+func (h *Handler) distance(w http.ResponseWriter, r *http.Request) {
+	var req pairRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, distanceResponse{Distance: sparse.Distance(req.A, req.B, sparse.Euclidean)})
+}
+
+// decode reads a JSON request body into dst, replying with an error and
+// returning false if it is malformed or too large.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, dst any) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBody)).Decode(dst)
+	if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+		return false
+	} else if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return false
+	}
+
+	return true
+}
+
+// writeJSON replies with v encoded as JSON, or with 500 Internal Server
+// Error if it cannot be encoded, as for a NaN or infinite value.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		buf.Reset()
+		code = http.StatusInternalServerError
+		json.NewEncoder(&buf).Encode(errorResponse{Error: err.Error()})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(buf.Bytes())
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, errorResponse{Error: err.Error()})
+}
@@ -0,0 +1,74 @@
+package sparsehttp
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+func serve(h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestSearchK(t *testing.T) {
+	h := NewHandler(sparse.NewMemoryStore(), WithMaxK(5))
+	query := `{"dim": 2, "data": {"0": 1}}`
+	for _, tt := range []struct {
+		k    string
+		code int
+	}{
+		{"1", http.StatusOK},
+		{"5", http.StatusOK},
+		{"0", http.StatusBadRequest},
+		{"-1", http.StatusBadRequest},
+		{"6", http.StatusBadRequest},
+	} {
+		w := serve(h, "POST", "/search", `{"vector": `+query+`, "k": `+tt.k+`}`)
+		if w.Code != tt.code {
+			t.Errorf("search k=%s: got %d, want %d: %s", tt.k, w.Code, tt.code, w.Body)
+		}
+	}
+}
+
+func TestSimilarityUndefined(t *testing.T) {
+	h := NewHandler(sparse.NewMemoryStore())
+	w := serve(h, "POST", "/similarity", `{"a": {"dim": 2, "data": {}}, "b": {"dim": 2, "data": {"0": 1}}}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "undefined") {
+		t.Fatalf("similarity of a zero vector: got %d %s", w.Code, w.Body)
+	}
+}
+
+func TestWriteJSONEncodeError(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeJSON(w, http.StatusOK, distanceResponse{Distance: math.Inf(1)})
+	if w.Code != http.StatusInternalServerError || w.Body.Len() == 0 {
+		t.Fatalf("writeJSON(+Inf): got %d %q", w.Code, w.Body)
+	}
+}
+
+func TestBodyLimit(t *testing.T) {
+	h := NewHandler(sparse.NewMemoryStore(), WithMaxBodyBytes(64))
+	small := `{"dim": 2, "data": {"0": 1}}`
+	if w := serve(h, "PUT", "/vectors/a", small); w.Code != http.StatusNoContent {
+		t.Errorf("small body: got %d, want %d: %s", w.Code, http.StatusNoContent, w.Body)
+	}
+
+	large := `{"dim": 2, "data": {"0": 1` + strings.Repeat(" ", 64) + `}}`
+	if w := serve(h, "PUT", "/vectors/a", large); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: got %d, want %d: %s", w.Code, http.StatusRequestEntityTooLarge, w.Body)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	h := NewHandler(sparse.NewMemoryStore())
+	w := serve(h, "POST", "/distance", `{"a": {"dim": 3, "data": {"0": 3}}, "b": {"dim": 3, "data": {"2": -4}}}`)
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"distance":5}` {
+		t.Errorf("distance: got %d %s, want 200 {\"distance\":5}", w.Code, w.Body)
+	}
+}
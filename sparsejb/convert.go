@@ -0,0 +1,100 @@
+// Package sparsejb bridges sparse types and the matrix formats of
+// github.com/james-bowman/sparse. It lives in its own package so the
+// core package does not depend on james-bowman/sparse.
+package sparsejb
+
+import (
+	"github.com/angadn/sparse"
+	jb "github.com/james-bowman/sparse"
+)
+
+// nonZeroDoer is implemented by the james-bowman matrix formats.
+type nonZeroDoer interface {
+	Dims() (r, c int)
+	DoNonZero(fn func(i, j int, v float64))
+}
+
+// rows splits a matrix into one sparse.Vector per row.
+func rows(m nonZeroDoer) []sparse.Vector {
+	r, c := m.Dims()
+	ret := make([]sparse.Vector, r)
+	for i := range ret {
+		ret[i] = sparse.NewVector(c)
+	}
+
+	m.DoNonZero(func(i, j int, v float64) {
+		ret[i].Set(j, v)
+	})
+
+	return ret
+}
+
+// FromCSR splits a CSR matrix into one sparse.Vector per row.
+func FromCSR(m *jb.CSR) []sparse.Vector {
+	return rows(m)
+}
+
+// FromCOO splits a COO matrix into one sparse.Vector per row.
+func FromCOO(m *jb.COO) []sparse.Vector {
+	return rows(m)
+}
+
+// ToCOO builds a COO matrix with one row per Vector. The number of
+// columns is the largest row dimensionality.
+func ToCOO(vs []sparse.Vector) *jb.COO {
+	var (
+		c    int
+		is   []int
+		js   []int
+		data []float64
+	)
+
+	for i, v := range vs {
+		if v.Size() > c {
+			c = v.Size()
+		}
+
+		for j, f := range v.NonZeros() {
+			if j >= 0 && j < v.Size() {
+				is = append(is, i)
+				js = append(js, j)
+				data = append(data, f)
+			}
+		}
+	}
+
+	return jb.NewCOO(len(vs), c, is, js, data)
+}
+
+// ToCSR builds a CSR matrix with one row per Vector. The number of
+// columns is the largest row dimensionality.
+func ToCSR(vs []sparse.Vector) *jb.CSR {
+	return ToCOO(vs).ToCSR()
+}
+
+// FromVector copies a james-bowman sparse vector into a sparse.Vector.
+func FromVector(v *jb.Vector) sparse.Vector {
+	ret := sparse.NewVector(v.Len())
+	v.DoNonZero(func(i, _ int, f float64) {
+		ret.Set(i, f)
+	})
+
+	return ret
+}
+
+// ToVector copies v into a james-bowman sparse vector.
+func ToVector(v sparse.Vector) *jb.Vector {
+	var (
+		ind  []int
+		data []float64
+	)
+
+	for i, f := range v.SortedNonZeros() {
+		if i >= 0 && i < v.Size() {
+			ind = append(ind, i)
+			data = append(data, f)
+		}
+	}
+
+	return jb.NewVector(v.Size(), ind, data)
+}
@@ -0,0 +1,10 @@
+module github.com/angadn/sparse/sparsejb
+
+go 1.24.0
+
+require (
+	github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
+	github.com/james-bowman/sparse v0.0.0-20260216202247-495ee4f84d35
+)
+
+require gonum.org/v1/gonum v0.17.0 // indirect
@@ -0,0 +1,67 @@
+// Package sparsekafka serializes sparse Vectors as Kafka message
+// values. The payload is the vector's binary encoding, optionally
+// framed in the Confluent schema registry envelope: a zero magic byte
+// followed by the big-endian uint32 schema id.
+package sparsekafka
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/angadn/sparse"
+)
+
+// magic is the first byte of a schema registry envelope. It never
+// starts a bare payload, whose first byte is the encoding version.
+const magic = 0
+
+// Serializer encodes Vectors for producing to Kafka.
+type Serializer struct {
+	// SchemaID, when non-zero, frames payloads in a schema registry
+	// envelope carrying this id.
+	SchemaID uint32
+}
+
+// Serialize encodes v. topic is unused, but matches the shape of the
+// common Go Kafka client serde interfaces.
+func (s Serializer) Serialize(topic string, v sparse.Vector) ([]byte, error) {
+	payload, err := v.MarshalBinary()
+	if err != nil || s.SchemaID == 0 {
+		return payload, err
+	}
+
+	ret := make([]byte, 5, 5+len(payload))
+	ret[0] = magic
+	binary.BigEndian.PutUint32(ret[1:], s.SchemaID)
+	return append(ret, payload...), nil
+}
+
+// Deserializer decodes Vectors consumed from Kafka. It accepts payloads
+// with or without a schema registry envelope.
+type Deserializer struct {
+	// SchemaID, when non-zero, rejects enveloped payloads carrying a
+	// different schema id.
+	SchemaID uint32
+}
+
+// Deserialize decodes a message value.
+func (d Deserializer) Deserialize(topic string, b []byte) (sparse.Vector, error) {
+	var v sparse.Vector
+	if len(b) > 0 && b[0] == magic {
+		if len(b) < 5 {
+			return v, fmt.Errorf("sparsekafka: %s: truncated envelope", topic)
+		}
+
+		if id := binary.BigEndian.Uint32(b[1:]); d.SchemaID != 0 && id != d.SchemaID {
+			return v, fmt.Errorf("sparsekafka: %s: schema id %d, want %d", topic, id, d.SchemaID)
+		}
+
+		b = b[5:]
+	}
+
+	if err := v.UnmarshalBinary(b); err != nil {
+		return v, fmt.Errorf("sparsekafka: %s: %w", topic, err)
+	}
+
+	return v, nil
+}
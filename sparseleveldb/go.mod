@@ -0,0 +1,10 @@
+module github.com/angadn/sparse/sparseleveldb
+
+go 1.24
+
+require (
+	github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
+	github.com/syndtr/goleveldb v1.0.1-0.20220721030215-126854af5e6d
+)
+
+require github.com/golang/snappy v0.0.4 // indirect
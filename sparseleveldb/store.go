@@ -0,0 +1,191 @@
+// Package sparseleveldb implements a sparse.Store in an embedded
+// LevelDB database, so collections survive restarts without a database
+// server.
+//
+// Writes are buffered into batches and committed to LevelDB once a
+// batch fills, on Flush, or on Close. Reads see buffered writes.
+package sparseleveldb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/angadn/sparse"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// DefaultBatchSize is the number of buffered writes that triggers a
+// commit.
+const DefaultBatchSize = 1024
+
+// keyPrefix namespaces vector keys in the database.
+const keyPrefix = "v/"
+
+// Store is a sparse.Store backed by LevelDB.
+type Store struct {
+	db        *leveldb.DB
+	batchSize int
+
+	mu      sync.RWMutex
+	batch   leveldb.Batch
+	pending map[string][]byte // nil values are pending deletes
+}
+
+var _ sparse.Store = (*Store)(nil)
+
+// Open opens, creating if needed, the database in dir. Writes are
+// committed every batchSize operations; batchSize <= 0 uses
+// DefaultBatchSize.
+func Open(dir string, batchSize int) (*Store, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Store{db: db, batchSize: batchSize, pending: map[string][]byte{}}, nil
+}
+
+// Close commits buffered writes and closes the database.
+func (s *Store) Close() error {
+	if err := s.Flush(); err != nil {
+		s.db.Close()
+		return err
+	}
+
+	return s.db.Close()
+}
+
+// Flush commits buffered writes.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flush()
+}
+
+func (s *Store) flush() error {
+	if s.batch.Len() == 0 {
+		return nil
+	}
+
+	if err := s.db.Write(&s.batch, nil); err != nil {
+		return err
+	}
+
+	s.batch.Reset()
+	clear(s.pending)
+	return nil
+}
+
+// write buffers a put, or a delete if data is nil.
+func (s *Store) write(id string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if data == nil {
+		s.batch.Delete([]byte(keyPrefix + id))
+	} else {
+		s.batch.Put([]byte(keyPrefix+id), data)
+	}
+
+	s.pending[id] = data
+	if s.batch.Len() >= s.batchSize {
+		return s.flush()
+	}
+
+	return nil
+}
+
+// Upsert buffers v to be stored under id.
+func (s *Store) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	return s.write(id, data)
+}
+
+// Get loads the Vector stored under id.
+func (s *Store) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	var v sparse.Vector
+	s.mu.RLock()
+	data, ok := s.pending[id]
+	s.mu.RUnlock()
+	if !ok {
+		var err error
+		data, err = s.db.Get([]byte(keyPrefix+id), nil)
+		if errors.Is(err, leveldb.ErrNotFound) {
+			return v, sparse.ErrNotFound
+		} else if err != nil {
+			return v, err
+		}
+	} else if data == nil {
+		return v, sparse.ErrNotFound
+	}
+
+	err := v.UnmarshalBinary(data)
+	return v, err
+}
+
+// Delete buffers the removal of the Vector stored under id.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	return s.write(id, nil)
+}
+
+// Len commits buffered writes, then counts the stored Vectors.
+func (s *Store) Len(ctx context.Context) (int, error) {
+	n := 0
+	err := s.iterate(ctx, func(id string, data []byte) (bool, error) {
+		n++
+		return true, nil
+	})
+
+	return n, err
+}
+
+// Scan commits buffered writes, then iterates the stored Vectors in id
+// order over a consistent snapshot of the database.
+func (s *Store) Scan(ctx context.Context, fn func(id string, v sparse.Vector) bool) error {
+	return s.iterate(ctx, func(id string, data []byte) (bool, error) {
+		var v sparse.Vector
+		if err := v.UnmarshalBinary(data); err != nil {
+			return false, err
+		}
+
+		return fn(id, v), nil
+	})
+}
+
+func (s *Store) iterate(ctx context.Context, fn func(id string, data []byte) (bool, error)) error {
+	if err := s.Flush(); err != nil {
+		return err
+	}
+
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return err
+	}
+
+	defer snap.Release()
+	it := snap.NewIterator(util.BytesPrefix([]byte(keyPrefix)), nil)
+	defer it.Release()
+	for it.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		more, err := fn(string(it.Key()[len(keyPrefix):]), it.Value())
+		if err != nil {
+			return err
+		} else if !more {
+			break
+		}
+	}
+
+	return it.Error()
+}
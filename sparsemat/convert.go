@@ -0,0 +1,107 @@
+package sparsemat
+
+import (
+	"github.com/angadn/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ToVecDense copies v into a newly allocated dense vector.
+func ToVecDense(v sparse.Vector) *mat.VecDense {
+	if v.Size() == 0 {
+		return &mat.VecDense{}
+	}
+
+	return mat.NewVecDense(v.Size(), v.ToDense())
+}
+
+// FromVecDense copies the non-zero elements of a gonum vector (such
+// as a *mat.VecDense) into a sparse.Vector.
+func FromVecDense(d mat.Vector) sparse.Vector {
+	ret := sparse.NewVector(d.Len())
+	for i := 0; i < d.Len(); i++ {
+		if f := d.AtVec(i); f != 0 {
+			ret.Set(i, f)
+		}
+	}
+
+	return ret
+}
+
+// ToDense copies rows into a newly allocated dense matrix, one row per
+// Vector. The number of columns is the largest row dimensionality.
+func ToDense(rows []sparse.Vector) *mat.Dense {
+	c := 0
+	for _, row := range rows {
+		if row.Size() > c {
+			c = row.Size()
+		}
+	}
+
+	if len(rows) == 0 || c == 0 {
+		return &mat.Dense{}
+	}
+
+	ret := mat.NewDense(len(rows), c, nil)
+	for i, row := range rows {
+		ret.SetRow(i, row.Grow(c).ToDense())
+	}
+
+	return ret
+}
+
+// FromDense copies the non-zero elements of each row of a gonum matrix
+// (such as a *mat.Dense) into a sparse.Vector.
+func FromDense(d mat.Matrix) []sparse.Vector {
+	r, c := d.Dims()
+	ret := make([]sparse.Vector, r)
+	for i := range ret {
+		ret[i] = sparse.NewVector(c)
+		for j := 0; j < c; j++ {
+			if f := d.At(i, j); f != 0 {
+				ret[i].Set(j, f)
+			}
+		}
+	}
+
+	return ret
+}
+
+// MatrixToDense copies m into a newly allocated dense matrix.
+func MatrixToDense(m sparse.Matrix) *mat.Dense {
+	r, c := m.Dims()
+	if r == 0 || c == 0 {
+		return &mat.Dense{}
+	}
+
+	m = m.ToCSR()
+	ret := mat.NewDense(r, c, nil)
+	for i := range r {
+		for j, f := range m.Row(i).NonZeros() {
+			ret.Set(i, j, f)
+		}
+	}
+
+	return ret
+}
+
+// MatrixFromDense copies the non-zero elements of a gonum matrix (such
+// as a *mat.Dense) into a CSR sparse.Matrix of the same dimensions.
+func MatrixFromDense(d mat.Matrix) sparse.Matrix {
+	r, c := d.Dims()
+	var is, js []int
+	var values []float64
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			if f := d.At(i, j); f != 0 {
+				is, js, values = append(is, i), append(js, j), append(values, f)
+			}
+		}
+	}
+
+	ret, err := sparse.NewMatrixFromTriplets(r, c, is, js, values, sparse.CSR)
+	if err != nil {
+		panic(err) // unreachable: every entry is within the dimensions
+	}
+
+	return ret
+}
@@ -0,0 +1,56 @@
+package sparsemat
+
+import (
+	"testing"
+
+	"github.com/angadn/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestMatrixDenseRoundTrip(t *testing.T) {
+	d := mat.NewDense(2, 3, []float64{
+		1, 0, -2,
+		0, 0, 3,
+	})
+
+	m := MatrixFromDense(d)
+	if r, c := m.Dims(); r != 2 || c != 3 || m.NNZ() != 3 {
+		t.Fatalf("MatrixFromDense() is %d×%d with %d entries, want 2×3 with 3", r, c, m.NNZ())
+	}
+
+	if got := MatrixToDense(m); !mat.Equal(got, d) {
+		t.Errorf("MatrixToDense() = %v, want %v", mat.Formatted(got), mat.Formatted(d))
+	} else if got := MatrixToDense(m.ToCSC()); !mat.Equal(got, d) {
+		t.Errorf("MatrixToDense() of CSC = %v, want %v", mat.Formatted(got), mat.Formatted(d))
+	}
+
+	if got := MatrixFromDense(&mat.Dense{}); got.NNZ() != 0 {
+		t.Errorf("MatrixFromDense() of an empty matrix has %d entries", got.NNZ())
+	}
+}
+
+func TestMatrixAdapter(t *testing.T) {
+	m := sparse.NewMatrixFromRows([]sparse.Vector{
+		sparse.NewVectorFromArray([]float64{1, 0, -2}),
+		sparse.NewVectorFromArray([]float64{0, 0, 3}),
+	})
+
+	a := NewMatrix(m)
+	if !mat.Equal(a, MatrixToDense(m)) {
+		t.Errorf("adapter = %v", mat.Formatted(a))
+	}
+
+	var product mat.Dense
+	product.Mul(a, a.T())
+	if want := mat.NewDense(2, 2, []float64{5, -6, -6, 9}); !mat.Equal(&product, want) {
+		t.Errorf("a aᵀ = %v, want %v", mat.Formatted(&product), mat.Formatted(want))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("At outside the matrix did not panic")
+		}
+	}()
+
+	a.At(2, 0)
+}
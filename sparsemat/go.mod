@@ -0,0 +1,8 @@
+module github.com/angadn/sparse/sparsemat
+
+go 1.24.0
+
+require (
+	github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
+	gonum.org/v1/gonum v0.17.0
+)
@@ -0,0 +1,48 @@
+package sparsemat
+
+import (
+	"github.com/angadn/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Matrix is a thin adapter that makes a sparse.Matrix satisfy
+// mat.Matrix. It shares the underlying matrix's storage, which is
+// immutable.
+type Matrix struct {
+	m sparse.Matrix
+}
+
+var _ mat.Matrix = Matrix{}
+
+// NewMatrix wraps m as a mat.Matrix.
+func NewMatrix(m sparse.Matrix) Matrix {
+	return Matrix{m: m}
+}
+
+// Sparse returns the wrapped sparse.Matrix.
+func (a Matrix) Sparse() sparse.Matrix {
+	return a.m
+}
+
+// Dims returns the dimensions of the matrix.
+func (a Matrix) Dims() (r, c int) {
+	return a.m.Dims()
+}
+
+// At returns the value at row i and column j, by binary search within
+// the row in CSR storage or the column in CSC storage.
+func (a Matrix) At(i, j int) float64 {
+	r, c := a.m.Dims()
+	if i < 0 || i >= r {
+		panic(mat.ErrRowAccess)
+	} else if j < 0 || j >= c {
+		panic(mat.ErrColAccess)
+	}
+
+	return a.m.At(i, j)
+}
+
+// T is the transpose of the matrix, which shares its storage.
+func (a Matrix) T() mat.Matrix {
+	return Matrix{m: a.m.T()}
+}
@@ -0,0 +1,60 @@
+// Package sparsemat adapts sparse Vectors and Matrices to gonum's mat
+// interfaces, so they can be handed directly to gonum routines without
+// copying, and converts them to and from gonum's dense types.
+package sparsemat
+
+import (
+	"github.com/angadn/sparse"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Vector is a thin adapter that makes a sparse.Vector satisfy
+// mat.Vector. It aliases the underlying vector's storage.
+type Vector struct {
+	v sparse.Vector
+}
+
+var _ mat.Vector = Vector{}
+
+// NewVector wraps v as a mat.Vector.
+func NewVector(v sparse.Vector) Vector {
+	return Vector{v: v}
+}
+
+// Sparse returns the wrapped sparse.Vector.
+func (a Vector) Sparse() sparse.Vector {
+	return a.v
+}
+
+// Dims returns the dimensions of the column vector.
+func (a Vector) Dims() (r, c int) {
+	return a.v.Size(), 1
+}
+
+// At returns the value at row i. j must be 0.
+func (a Vector) At(i, j int) float64 {
+	if j != 0 {
+		panic(mat.ErrColAccess)
+	}
+
+	return a.AtVec(i)
+}
+
+// AtVec returns the i'th element of the vector.
+func (a Vector) AtVec(i int) float64 {
+	if i < 0 || i >= a.v.Size() {
+		panic(mat.ErrRowAccess)
+	}
+
+	return a.v.Get(i)
+}
+
+// Len is the length of the vector.
+func (a Vector) Len() int {
+	return a.v.Size()
+}
+
+// T is the transpose of the vector, a 1×n row matrix.
+func (a Vector) T() mat.Matrix {
+	return mat.Transpose{Matrix: a}
+}
@@ -0,0 +1,264 @@
+// Package sparseml trains linear and logistic regression models directly
+// over sparse Vectors, such as those produced by sparse.Hasher or
+// sparsetext.Vectorizer. Training only touches the dimensions each
+// sample stores, and L1 regularization keeps the learned weights sparse.
+package sparseml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/angadn/sparse"
+)
+
+// ErrLabel is returned by Fit for a logistic Model given a label other
+// than 0 or 1.
+var ErrLabel = errors.New("sparseml: logistic labels must be 0 or 1")
+
+// Loss is the objective a Model minimizes.
+type Loss int
+
+const (
+	// Squared is the squared error, fitting a linear regression.
+	Squared Loss = iota
+
+	// Logistic is the log loss, fitting a logistic regression whose
+	// predictions are probabilities of the label 1.
+	Logistic
+)
+
+// Optimizer is the algorithm a Model is trained with.
+type Optimizer int
+
+const (
+	// SGD is stochastic gradient descent with a learning rate decaying
+	// by epoch, applying L1 regularization as a cumulative penalty that
+	// clips weights to zero.
+	SGD Optimizer = iota
+
+	// FTRL is the FTRL-Proximal algorithm, with per-coordinate learning
+	// rates. It suits very sparse, high-dimensional features, and its
+	// L1 regularization yields exact zeros.
+	FTRL
+)
+
+const (
+	// DefaultLearningRate is the learning rate used when a Model's is
+	// zero.
+	DefaultLearningRate = 0.1
+
+	// DefaultEpochs is the number of passes used when a Model's Epochs
+	// is zero.
+	DefaultEpochs = 5
+
+	// ftrlBeta smooths FTRL's per-coordinate learning rates early on.
+	ftrlBeta = 1
+)
+
+// Model is a linear model over sparse Vectors. A zero Model is a linear
+// regression trained by SGD without regularization; the exported fields
+// adjust this and must be set before Fit.
+type Model struct {
+	// Loss is the objective to minimize.
+	Loss Loss
+
+	// Optimizer is the training algorithm.
+	Optimizer Optimizer
+
+	// LearningRate is the initial step size for SGD, and the alpha
+	// parameter of FTRL.
+	LearningRate float64
+
+	// L1 and L2 are the strengths of L1 and L2 regularization of the
+	// weights. The bias is not regularized.
+	L1 float64
+	L2 float64
+
+	// Epochs is the number of passes over the samples.
+	Epochs int
+
+	// Seed seeds the shuffling of the samples before each epoch.
+	Seed uint64
+
+	dim     int
+	weights map[int]float64
+	bias    float64
+}
+
+// trainer is the state of a Model's optimizer during Fit.
+type trainer struct {
+	m    *Model
+	rate float64
+
+	// SGD: the total L1 penalty each weight could have received, and
+	// the penalty each has.
+	penalty float64
+	applied map[int]float64
+
+	// FTRL: the accumulated adjusted gradients and squared gradients of
+	// each weight and the bias.
+	z, n         map[int]float64
+	biasZ, biasN float64
+}
+
+// Fit trains the Model on samples and their labels, replacing any
+// earlier training. It returns sparse.ErrDimensionMismatch unless there
+// is one label per sample, and ErrLabel for an invalid logistic label.
+func (m *Model) Fit(samples []sparse.Vector, labels []float64) error {
+	if len(samples) != len(labels) {
+		return fmt.Errorf("%w: %d samples but %d labels", sparse.ErrDimensionMismatch, len(samples), len(labels))
+	} else if m.Loss == Logistic {
+		for i, y := range labels {
+			if y != 0 && y != 1 {
+				return fmt.Errorf("%w: label %d is %g", ErrLabel, i, y)
+			}
+		}
+	}
+
+	m.dim, m.weights, m.bias = 0, map[int]float64{}, 0
+	for _, x := range samples {
+		m.dim = max(m.dim, x.Size())
+	}
+
+	t := &trainer{m: m, rate: m.LearningRate, applied: map[int]float64{}, z: map[int]float64{}, n: map[int]float64{}}
+	if t.rate == 0 {
+		t.rate = DefaultLearningRate
+	}
+
+	epochs := m.Epochs
+	if epochs == 0 {
+		epochs = DefaultEpochs
+	}
+
+	r := rand.New(rand.NewPCG(m.Seed, 0))
+	order := make([]int, len(samples))
+	for i := range order {
+		order[i] = i
+	}
+
+	for epoch := range epochs {
+		r.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		for _, i := range order {
+			if m.Optimizer == FTRL {
+				t.ftrl(samples[i], labels[i])
+			} else {
+				t.sgd(samples[i], labels[i], t.rate/math.Sqrt(float64(epoch+1)))
+			}
+		}
+	}
+
+	for i, w := range m.weights {
+		if w == 0 {
+			delete(m.weights, i)
+		}
+	}
+
+	return nil
+}
+
+// gradient is the derivative of the Model's loss with respect to the
+// margin of a sample labelled y, which for both losses is the
+// prediction minus the label.
+func (m *Model) gradient(margin float64, y float64) float64 {
+	return m.link(margin) - y
+}
+
+// link maps a margin to a prediction.
+func (m *Model) link(margin float64) float64 {
+	if m.Loss == Logistic {
+		return 1 / (1 + math.Exp(-margin))
+	}
+
+	return margin
+}
+
+// sgd takes a gradient step on the sample x at the given learning rate,
+// regularizing only the weights x touches.
+func (t *trainer) sgd(x sparse.Vector, y float64, rate float64) {
+	m := t.m
+	g := m.gradient(m.margin(x), y)
+	m.bias -= rate * g
+	t.penalty += rate * m.L1
+	for i, d := range x.NonZeros() {
+		w := m.weights[i] - rate*(g*d+m.L2*m.weights[i])
+
+		// Move w towards zero by the penalty it is yet to receive,
+		// without crossing it.
+		clipped := w
+		if w > 0 {
+			clipped = max(0, w-(t.penalty+t.applied[i]))
+		} else if w < 0 {
+			clipped = min(0, w+(t.penalty-t.applied[i]))
+		}
+
+		t.applied[i] += clipped - w
+		m.weights[i] = clipped
+	}
+}
+
+// ftrl takes an FTRL-Proximal step on the sample x.
+func (t *trainer) ftrl(x sparse.Vector, y float64) {
+	m := t.m
+	for i := range x.NonZeros() {
+		m.weights[i] = t.ftrlWeight(t.z[i], t.n[i], m.L1, m.L2)
+	}
+
+	m.bias = t.ftrlWeight(t.biasZ, t.biasN, 0, 0)
+	g := m.gradient(m.margin(x), y)
+	for i, d := range x.NonZeros() {
+		gi := g * d
+		sigma := (math.Sqrt(t.n[i]+gi*gi) - math.Sqrt(t.n[i])) / t.rate
+		t.z[i] += gi - sigma*m.weights[i]
+		t.n[i] += gi * gi
+		m.weights[i] = t.ftrlWeight(t.z[i], t.n[i], m.L1, m.L2)
+	}
+
+	sigma := (math.Sqrt(t.biasN+g*g) - math.Sqrt(t.biasN)) / t.rate
+	t.biasZ += g - sigma*m.bias
+	t.biasN += g * g
+	m.bias = t.ftrlWeight(t.biasZ, t.biasN, 0, 0)
+}
+
+// ftrlWeight is the FTRL-Proximal weight of a coordinate with the given
+// state, which is exactly zero while |z| is within the L1 strength.
+func (t *trainer) ftrlWeight(z float64, n float64, l1 float64, l2 float64) float64 {
+	if math.Abs(z) <= l1 {
+		return 0
+	}
+
+	return -(z - math.Copysign(l1, z)) / ((ftrlBeta+math.Sqrt(n))/t.rate + l2)
+}
+
+// margin is the bias plus the dot product of the weights with x.
+func (m *Model) margin(x sparse.Vector) float64 {
+	ret := m.bias
+	for i, d := range x.NonZeros() {
+		ret += m.weights[i] * d
+	}
+
+	return ret
+}
+
+// Predict the label of x: the regression's estimate for a Squared
+// Model, or the probability of the label 1 for a Logistic one.
+func (m *Model) Predict(x sparse.Vector) float64 {
+	return m.link(m.margin(x))
+}
+
+// Weights returns the learned weights, without those L1 regularization
+// zeroed, with the largest dimensionality among the training samples.
+func (m *Model) Weights() sparse.Vector {
+	ret := sparse.NewVector(m.dim, sparse.WithCapacity(len(m.weights)))
+	for i, w := range m.weights {
+		ret.Set(i, w)
+	}
+
+	return ret
+}
+
+// Bias returns the learned intercept.
+func (m *Model) Bias() float64 {
+	return m.bias
+}
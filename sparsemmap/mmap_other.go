@@ -0,0 +1,16 @@
+//go:build !unix
+
+package sparsemmap
+
+import "os"
+
+// mmapFile reads the file at path into memory on platforms without
+// mmap, returning its contents and a no-op unmap function.
+func mmapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return data, func() error { return nil }, nil
+}
@@ -0,0 +1,35 @@
+//go:build unix
+
+package sparsemmap
+
+import (
+	"math"
+	"os"
+	"syscall"
+)
+
+// mmapFile maps the file at path read-only, returning its contents and
+// a function that unmaps them.
+func mmapFile(path string) ([]byte, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	} else if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	} else if info.Size() > math.MaxInt {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: syscall.EFBIG}
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
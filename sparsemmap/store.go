@@ -0,0 +1,221 @@
+// Package sparsemmap stores a collection of Vectors in a single file
+// that is memory-mapped for reading, so a corpus larger than RAM can be
+// searched without decoding it onto the Go heap. Only the pages a query
+// touches are read, and the operating system caches them.
+//
+// Files are written by a Writer and read by Open. A file is the magic
+// "SPMM0001", then each Vector as its little-endian uint64 dim and
+// entry count, ascending uint64 indices and float64 values, and finally
+// the uint64 offset of each Vector, the offset of that index and the
+// number of Vectors. Every field is 8-byte aligned.
+package sparsemmap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"sort"
+
+	"github.com/angadn/sparse"
+)
+
+const magic = "SPMM0001"
+
+// ErrMalformed is returned by Open for a file that is not a valid
+// sparsemmap file.
+var ErrMalformed = errors.New("sparsemmap: malformed file")
+
+// Store is a read-only, memory-mapped collection of Vectors. It is safe
+// for concurrent use until Close. Unlike a sparse.Store, its Vectors are
+// addressed by position rather than id.
+type Store struct {
+	data    []byte
+	offsets []byte // the index: one uint64 offset per Vector
+	n       int
+	unmap   func() error
+}
+
+// Open maps the file at path.
+func Open(path string) (*Store, error) {
+	data, unmap, err := mmapFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := newStore(data)
+	if err != nil {
+		unmap()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	s.unmap = unmap
+	return s, nil
+}
+
+// newStore validates the layout of data and indexes it, including that
+// each Vector's indices ascend and fall within its dim, so that a View
+// never needs to check them.
+func newStore(data []byte) (*Store, error) {
+	size := uint64(len(data))
+	if size < uint64(len(magic))+16 || string(data[:len(magic)]) != magic {
+		return nil, ErrMalformed
+	}
+
+	n := binary.LittleEndian.Uint64(data[size-8:])
+	index := binary.LittleEndian.Uint64(data[size-16:])
+	if index < uint64(len(magic)) || index > size-16 || (size-16-index)%8 != 0 || (size-16-index)/8 != n {
+		return nil, ErrMalformed
+	} else if n > (index-uint64(len(magic)))/16 {
+		return nil, ErrMalformed // each Vector takes at least 16 bytes
+	}
+
+	s := &Store{data: data[:index], offsets: data[index : size-16], n: int(n)}
+	for i := range s.n {
+		off := binary.LittleEndian.Uint64(s.offsets[8*i:])
+		if off > index-16 {
+			return nil, ErrMalformed
+		}
+
+		dim := binary.LittleEndian.Uint64(s.data[off:])
+		nnz := binary.LittleEndian.Uint64(s.data[off+8:])
+		if dim > math.MaxInt || nnz > (index-16-off)/16 {
+			return nil, ErrMalformed
+		}
+
+		prev := uint64(0)
+		for j := range nnz {
+			n := binary.LittleEndian.Uint64(s.data[off+16+8*j:])
+			if n >= dim || (j > 0 && n <= prev) {
+				return nil, ErrMalformed
+			}
+
+			prev = n
+		}
+	}
+
+	return s, nil
+}
+
+// Len is the number of Vectors in the Store.
+func (s *Store) Len() int {
+	return s.n
+}
+
+// At returns a View of the i'th Vector, which reads the mapped file
+// directly. It panics if i is out of range.
+func (s *Store) At(i int) View {
+	if i < 0 || i >= s.n {
+		panic(fmt.Errorf("%w: %d not in [0, %d)", sparse.ErrOutOfRange, i, s.n))
+	}
+
+	off := binary.LittleEndian.Uint64(s.offsets[8*i:])
+	nnz := int(binary.LittleEndian.Uint64(s.data[off+8:]))
+	return View{
+		dim:     int(binary.LittleEndian.Uint64(s.data[off:])),
+		indices: s.data[off+16 : off+16+8*uint64(nnz)],
+		values:  s.data[off+16+8*uint64(nnz) : off+16+16*uint64(nnz)],
+	}
+}
+
+// All returns an iterator over the position and View of every Vector in
+// the Store.
+func (s *Store) All() iter.Seq2[int, View] {
+	return func(yield func(int, View) bool) {
+		for i := range s.n {
+			if !yield(i, s.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Close unmaps the file. Views obtained from the Store must not be used
+// afterwards.
+func (s *Store) Close() error {
+	if s.unmap == nil {
+		return nil
+	}
+
+	err := s.unmap()
+	s.unmap = nil
+	return err
+}
+
+// View is a read-only Vector held in a Store's mapped file.
+type View struct {
+	dim     int
+	indices []byte
+	values  []byte
+}
+
+// Size is the dimensionality of the vector.
+func (v View) Size() int {
+	return v.dim
+}
+
+// NNZ is the number of stored entries.
+func (v View) NNZ() int {
+	return len(v.indices) / 8
+}
+
+func (v View) index(i int) int {
+	return int(binary.LittleEndian.Uint64(v.indices[8*i:]))
+}
+
+func (v View) value(i int) float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(v.values[8*i:]))
+}
+
+// Get data from the n'th dimension, by binary search.
+func (v View) Get(n int) float64 {
+	i := sort.Search(v.NNZ(), func(i int) bool { return v.index(i) >= n })
+	if i < v.NNZ() && v.index(i) == n {
+		return v.value(i)
+	}
+
+	return 0
+}
+
+// NonZeros returns an iterator over the index and value of each stored
+// entry, in ascending index order.
+func (v View) NonZeros() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i := range v.NNZ() {
+			if !yield(v.index(i), v.value(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Dot product with query, without copying the View.
+func (v View) Dot(query sparse.Vector) float64 {
+	ret := float64(0)
+	for i := range v.NNZ() {
+		ret += v.value(i) * query.Get(v.index(i))
+	}
+
+	return ret
+}
+
+// Magnitude (scalar) of the vector.
+func (v View) Magnitude() float64 {
+	ret := float64(0)
+	for i := range v.NNZ() {
+		ret += v.value(i) * v.value(i)
+	}
+
+	return math.Sqrt(ret)
+}
+
+// Vector copies the View onto the heap as a sparse.Vector.
+func (v View) Vector() sparse.Vector {
+	ret := sparse.NewVector(v.dim, sparse.WithCapacity(v.NNZ()))
+	for n, d := range v.NonZeros() {
+		ret.Set(n, d)
+	}
+
+	return ret
+}
@@ -0,0 +1,70 @@
+package sparsemmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+// write returns the file a Writer makes of vs.
+func write(t *testing.T, vs ...sparse.Vector) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	wr, err := NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range vs {
+		if err := wr.Add(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := wr.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestOpen(t *testing.T) {
+	v := sparse.NewVectorFromArray([]float64{0, 1.5, 0, -2})
+	path := filepath.Join(t.TempDir(), "vectors.spmm")
+	if err := os.WriteFile(path, write(t, v, sparse.NewVector(2)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer s.Close()
+	if s.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", s.Len())
+	} else if got := s.At(0).Vector(); !sparse.Equal(got, v) {
+		t.Errorf("At(0) = %v, want %v", got, v)
+	}
+}
+
+func TestNewStoreBadIndices(t *testing.T) {
+	v := sparse.NewVectorFromArray([]float64{1, 0, 2})
+	first := len(magic) + 16 // the first index of the first Vector
+	for name, corrupt := range map[string]func([]byte){
+		"beyond dim": func(data []byte) { binary.LittleEndian.PutUint64(data[first+8:], 3) },
+		"descending": func(data []byte) { binary.LittleEndian.PutUint64(data[first:], 2) },
+		"repeated":   func(data []byte) { binary.LittleEndian.PutUint64(data[first+8:], 0) },
+	} {
+		data := write(t, v)
+		corrupt(data)
+		if _, err := newStore(data); !errors.Is(err, ErrMalformed) {
+			t.Errorf("%s: got %v, want ErrMalformed", name, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package sparsemmap
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/angadn/sparse"
+)
+
+// Writer writes Vectors to a file in the layout Open maps. Vectors are
+// streamed to the underlying writer as they are added, and the index
+// of their offsets is written by Close.
+type Writer struct {
+	w       *bufio.Writer
+	offsets []uint64
+	pos     uint64
+	buf     []byte
+}
+
+// NewWriter returns a Writer writing to w.
+func NewWriter(w io.Writer) (*Writer, error) {
+	wr := &Writer{w: bufio.NewWriter(w)}
+	if _, err := wr.w.WriteString(magic); err != nil {
+		return nil, err
+	}
+
+	wr.pos = uint64(len(magic))
+	return wr, nil
+}
+
+// Add appends v to the file, leaving out stored zeros and entries
+// outside its dimensions.
+func (wr *Writer) Add(v sparse.Vector) error {
+	var indices []uint64
+	var values []float64
+	for n, d := range v.SortedNonZeros() {
+		if n >= 0 && n < v.Size() {
+			indices = append(indices, uint64(n))
+			values = append(values, d)
+		}
+	}
+
+	wr.buf = binary.LittleEndian.AppendUint64(wr.buf[:0], uint64(v.Size()))
+	wr.buf = binary.LittleEndian.AppendUint64(wr.buf, uint64(len(indices)))
+	for _, n := range indices {
+		wr.buf = binary.LittleEndian.AppendUint64(wr.buf, n)
+	}
+
+	for _, d := range values {
+		wr.buf = binary.LittleEndian.AppendUint64(wr.buf, math.Float64bits(d))
+	}
+
+	if _, err := wr.w.Write(wr.buf); err != nil {
+		return err
+	}
+
+	wr.offsets = append(wr.offsets, wr.pos)
+	wr.pos += uint64(len(wr.buf))
+	return nil
+}
+
+// Close writes the index and flushes the file. It does not close the
+// underlying writer.
+func (wr *Writer) Close() error {
+	wr.buf = wr.buf[:0]
+	for _, off := range wr.offsets {
+		wr.buf = binary.LittleEndian.AppendUint64(wr.buf, off)
+	}
+
+	wr.buf = binary.LittleEndian.AppendUint64(wr.buf, wr.pos)
+	wr.buf = binary.LittleEndian.AppendUint64(wr.buf, uint64(len(wr.offsets)))
+	if _, err := wr.w.Write(wr.buf); err != nil {
+		return err
+	}
+
+	return wr.w.Flush()
+}
@@ -0,0 +1,8 @@
+module github.com/angadn/sparse/sparseonnx
+
+go 1.24
+
+require (
+	github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
+	google.golang.org/protobuf v1.36.11
+)
@@ -0,0 +1,290 @@
+// Package sparseonnx converts sparse Vectors to and from serialized
+// ONNX SparseTensorProto messages, so sparse features can be fed to ONNX
+// Runtime without densifying them.
+//
+// Only the fields needed for 1-D tensors are handled: a vector of dim
+// dimensions is a SparseTensorProto of dims [dim], with int64 indices
+// and double values.
+package sparseonnx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/angadn/sparse"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ONNX TensorProto.DataType values.
+const (
+	dataTypeFloat  = 1
+	dataTypeInt64  = 7
+	dataTypeDouble = 11
+)
+
+// SparseTensorProto field numbers.
+const (
+	sparseValues  = 1
+	sparseIndices = 2
+	sparseDims    = 3
+)
+
+// TensorProto field numbers.
+const (
+	tensorDims       = 1
+	tensorDataType   = 2
+	tensorFloatData  = 4
+	tensorInt64Data  = 7
+	tensorRawData    = 9
+	tensorDoubleData = 10
+)
+
+var errMalformed = errors.New("sparseonnx: malformed SparseTensorProto")
+
+// Marshal encodes v as a SparseTensorProto.
+func Marshal(v sparse.Vector) []byte {
+	var (
+		indices []int64
+		values  []float64
+	)
+
+	for n, d := range v.SortedNonZeros() {
+		if n >= 0 && n < v.Size() {
+			indices = append(indices, int64(n))
+			values = append(values, d)
+		}
+	}
+
+	var valuesMsg, indicesMsg []byte
+	valuesMsg = appendPacked(valuesMsg, tensorDims, []int64{int64(len(values))})
+	valuesMsg = protowire.AppendTag(valuesMsg, tensorDataType, protowire.VarintType)
+	valuesMsg = protowire.AppendVarint(valuesMsg, dataTypeDouble)
+	var packed []byte
+	for _, d := range values {
+		packed = protowire.AppendFixed64(packed, math.Float64bits(d))
+	}
+
+	valuesMsg = protowire.AppendTag(valuesMsg, tensorDoubleData, protowire.BytesType)
+	valuesMsg = protowire.AppendBytes(valuesMsg, packed)
+
+	indicesMsg = appendPacked(indicesMsg, tensorDims, []int64{int64(len(indices))})
+	indicesMsg = protowire.AppendTag(indicesMsg, tensorDataType, protowire.VarintType)
+	indicesMsg = protowire.AppendVarint(indicesMsg, dataTypeInt64)
+	indicesMsg = appendPacked(indicesMsg, tensorInt64Data, indices)
+
+	var ret []byte
+	ret = protowire.AppendTag(ret, sparseValues, protowire.BytesType)
+	ret = protowire.AppendBytes(ret, valuesMsg)
+	ret = protowire.AppendTag(ret, sparseIndices, protowire.BytesType)
+	ret = protowire.AppendBytes(ret, indicesMsg)
+	ret = appendPacked(ret, sparseDims, []int64{int64(v.Size())})
+	return ret
+}
+
+// appendPacked appends a packed repeated int64 field.
+func appendPacked(b []byte, num protowire.Number, xs []int64) []byte {
+	var packed []byte
+	for _, x := range xs {
+		packed = protowire.AppendVarint(packed, uint64(x))
+	}
+
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, packed)
+}
+
+// tensor is the subset of a TensorProto we read.
+type tensor struct {
+	dims     []int64
+	dataType int32
+	floats   []float64
+	int64s   []int64
+	raw      []byte
+}
+
+// Unmarshal decodes a 1-D SparseTensorProto into a Vector. Indices may
+// be given either as a [NNZ] or a [NNZ, 1] tensor, and values may be
+// float or double, in typed or raw fields.
+func Unmarshal(b []byte) (sparse.Vector, error) {
+	var (
+		values, indices tensor
+		dims            []int64
+	)
+
+	err := fields(b, func(num protowire.Number, typ protowire.Type, b []byte) error {
+		var err error
+		switch num {
+		case sparseValues:
+			values, err = parseTensor(b)
+		case sparseIndices:
+			indices, err = parseTensor(b)
+		case sparseDims:
+			dims, err = appendInt64s(dims, typ, b)
+		}
+
+		return err
+	})
+	if err != nil {
+		return sparse.Vector{}, err
+	} else if len(dims) != 1 || dims[0] < 0 {
+		return sparse.Vector{}, fmt.Errorf("sparseonnx: dims %v is not a vector", dims)
+	}
+
+	idx, err := indices.int64Data()
+	if err != nil {
+		return sparse.Vector{}, err
+	}
+
+	vals, err := values.floatData()
+	if err != nil {
+		return sparse.Vector{}, err
+	} else if len(idx) != len(vals) {
+		return sparse.Vector{}, fmt.Errorf("sparseonnx: %d indices for %d values", len(idx), len(vals))
+	}
+
+	ret := sparse.NewVector(int(dims[0]))
+	for i, n := range idx {
+		if n < 0 || n >= dims[0] {
+			return sparse.Vector{}, fmt.Errorf("sparseonnx: index %d out of range for dim %d", n, dims[0])
+		}
+
+		ret.Set(int(n), vals[i])
+	}
+
+	return ret, nil
+}
+
+// fields calls fn with each field of a message. Non-length-delimited
+// fields are passed fn in their raw wire form.
+func fields(b []byte, fn func(num protowire.Number, typ protowire.Type, b []byte) error) error {
+	for len(b) > 0 {
+		num, typ, k := protowire.ConsumeTag(b)
+		if k < 0 {
+			return errMalformed
+		}
+
+		b = b[k:]
+		k = protowire.ConsumeFieldValue(num, typ, b)
+		if k < 0 {
+			return errMalformed
+		}
+
+		val := b[:k]
+		if typ == protowire.BytesType {
+			val, _ = protowire.ConsumeBytes(val)
+		}
+
+		if err := fn(num, typ, val); err != nil {
+			return err
+		}
+
+		b = b[k:]
+	}
+
+	return nil
+}
+
+func parseTensor(b []byte) (tensor, error) {
+	var t tensor
+	err := fields(b, func(num protowire.Number, typ protowire.Type, b []byte) error {
+		var err error
+		switch num {
+		case tensorDims:
+			t.dims, err = appendInt64s(t.dims, typ, b)
+		case tensorDataType:
+			x, k := protowire.ConsumeVarint(b)
+			if k < 0 {
+				return errMalformed
+			}
+
+			t.dataType = int32(x)
+		case tensorInt64Data:
+			t.int64s, err = appendInt64s(t.int64s, typ, b)
+		case tensorFloatData:
+			t.floats, err = appendFixed(t.floats, typ, b, 4)
+		case tensorDoubleData:
+			t.floats, err = appendFixed(t.floats, typ, b, 8)
+		case tensorRawData:
+			t.raw = b
+		}
+
+		return err
+	})
+
+	return t, err
+}
+
+// appendInt64s decodes a packed or unpacked repeated varint field.
+func appendInt64s(xs []int64, typ protowire.Type, b []byte) ([]int64, error) {
+	if typ != protowire.BytesType && typ != protowire.VarintType {
+		return nil, errMalformed
+	}
+
+	for len(b) > 0 {
+		x, k := protowire.ConsumeVarint(b)
+		if k < 0 {
+			return nil, errMalformed
+		}
+
+		xs = append(xs, int64(x))
+		b = b[k:]
+	}
+
+	return xs, nil
+}
+
+// appendFixed decodes a packed or unpacked repeated float (size 4) or
+// double (size 8) field.
+func appendFixed(xs []float64, typ protowire.Type, b []byte, size int) ([]float64, error) {
+	if len(b)%size != 0 || (typ != protowire.BytesType && typ != protowire.Fixed32Type && typ != protowire.Fixed64Type) {
+		return nil, errMalformed
+	}
+
+	for ; len(b) > 0; b = b[size:] {
+		if size == 4 {
+			xs = append(xs, float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+		} else {
+			xs = append(xs, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		}
+	}
+
+	return xs, nil
+}
+
+func (t tensor) int64Data() ([]int64, error) {
+	if len(t.dims) == 2 && t.dims[1] != 1 {
+		return nil, fmt.Errorf("sparseonnx: indices of shape %v are not for a vector", t.dims)
+	} else if t.dataType != dataTypeInt64 {
+		return nil, fmt.Errorf("sparseonnx: unsupported index data type %d", t.dataType)
+	} else if t.raw == nil {
+		return t.int64s, nil
+	} else if len(t.raw)%8 != 0 {
+		return nil, errMalformed
+	}
+
+	ret := make([]int64, len(t.raw)/8)
+	for i := range ret {
+		ret[i] = int64(binary.LittleEndian.Uint64(t.raw[8*i:]))
+	}
+
+	return ret, nil
+}
+
+func (t tensor) floatData() ([]float64, error) {
+	var size int
+	switch t.dataType {
+	case dataTypeFloat:
+		size = 4
+	case dataTypeDouble:
+		size = 8
+	default:
+		return nil, fmt.Errorf("sparseonnx: unsupported value data type %d", t.dataType)
+	}
+
+	if t.raw == nil {
+		return t.floats, nil
+	}
+
+	return appendFixed(nil, protowire.BytesType, t.raw, size)
+}
@@ -0,0 +1,36 @@
+// Package sparseotel traces sparse's heavy operations with
+// OpenTelemetry.
+package sparseotel
+
+import (
+	"context"
+
+	"github.com/angadn/sparse"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer adapts an OpenTelemetry tracer to sparse.Tracer.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ sparse.Tracer = Tracer{}
+
+// NewTracer wraps t. Install the result with sparse.SetTracer.
+func NewTracer(t trace.Tracer) Tracer {
+	return Tracer{tracer: t}
+}
+
+// Start starts a span named "sparse.<op>".
+func (t Tracer) Start(ctx context.Context, op string) (context.Context, func(err error)) {
+	ctx, span := t.tracer.Start(ctx, "sparse."+op)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		span.End()
+	}
+}
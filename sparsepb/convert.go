@@ -0,0 +1,43 @@
+package sparsepb
+
+import (
+	"fmt"
+
+	"github.com/angadn/sparse"
+)
+
+// ToProto converts v to its wire form, with indices in ascending order.
+// Stored zeros and entries outside v's dimensions are left out.
+func ToProto(v sparse.Vector) *Vector {
+	ret := &Vector{Dim: int64(v.Size())}
+	for n, d := range v.SortedNonZeros() {
+		if n >= 0 && n < v.Size() {
+			ret.Indices = append(ret.Indices, int64(n))
+			ret.Values = append(ret.Values, d)
+		}
+	}
+
+	return ret
+}
+
+// FromProto converts a wire Vector, as sent by any language's protobuf
+// runtime. It returns sparse.ErrDimensionMismatch if indices and values
+// differ in length, sparse.ErrOutOfRange for an index outside the
+// dimensions, and sparse.ErrDuplicateIndex for a repeated index. A nil
+// message is a zero-dimensional Vector.
+func FromProto(pv *Vector) (sparse.Vector, error) {
+	if pv.GetDim() < 0 {
+		return sparse.Vector{}, fmt.Errorf("%w: negative dim %d", sparse.ErrOutOfRange, pv.GetDim())
+	}
+
+	indices := make([]int, len(pv.GetIndices()))
+	for i, n := range pv.GetIndices() {
+		if n < 0 || n >= pv.GetDim() {
+			return sparse.Vector{}, fmt.Errorf("%w: %d not in [0, %d)", sparse.ErrOutOfRange, n, pv.GetDim())
+		}
+
+		indices[i] = int(n)
+	}
+
+	return sparse.NewVectorFromPairs(int(pv.GetDim()), indices, pv.GetValues())
+}
@@ -0,0 +1,7 @@
+// Package sparsepb holds the protobuf messages and gRPC service
+// definitions for exchanging sparse vectors, and conversions between
+// the Vector message and sparse.Vector. The schema in sparse.proto is
+// the canonical wire form for services in other languages.
+package sparsepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative sparse.proto
@@ -0,0 +1,797 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: sparse.proto
+
+package sparsepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ArithmeticRequest_Op int32
+
+const (
+	ArithmeticRequest_OP_UNSPECIFIED ArithmeticRequest_Op = 0
+	// ADD computes a + b.
+	ArithmeticRequest_OP_ADD ArithmeticRequest_Op = 1
+	// TIMES computes a * scalar.
+	ArithmeticRequest_OP_TIMES ArithmeticRequest_Op = 2
+	// APPEND concatenates b onto a.
+	ArithmeticRequest_OP_APPEND ArithmeticRequest_Op = 3
+)
+
+// Enum value maps for ArithmeticRequest_Op.
+var (
+	ArithmeticRequest_Op_name = map[int32]string{
+		0: "OP_UNSPECIFIED",
+		1: "OP_ADD",
+		2: "OP_TIMES",
+		3: "OP_APPEND",
+	}
+	ArithmeticRequest_Op_value = map[string]int32{
+		"OP_UNSPECIFIED": 0,
+		"OP_ADD":         1,
+		"OP_TIMES":       2,
+		"OP_APPEND":      3,
+	}
+)
+
+func (x ArithmeticRequest_Op) Enum() *ArithmeticRequest_Op {
+	p := new(ArithmeticRequest_Op)
+	*p = x
+	return p
+}
+
+func (x ArithmeticRequest_Op) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArithmeticRequest_Op) Descriptor() protoreflect.EnumDescriptor {
+	return file_sparse_proto_enumTypes[0].Descriptor()
+}
+
+func (ArithmeticRequest_Op) Type() protoreflect.EnumType {
+	return &file_sparse_proto_enumTypes[0]
+}
+
+func (x ArithmeticRequest_Op) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArithmeticRequest_Op.Descriptor instead.
+func (ArithmeticRequest_Op) EnumDescriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{10, 0}
+}
+
+// Vector is a sparse vector of dim dimensions. indices and values are
+// parallel arrays holding the non-zero components.
+type Vector struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dim           int64                  `protobuf:"varint,1,opt,name=dim,proto3" json:"dim,omitempty"`
+	Indices       []int64                `protobuf:"varint,2,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+	Values        []float64              `protobuf:"fixed64,3,rep,packed,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vector) Reset() {
+	*x = Vector{}
+	mi := &file_sparse_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector) ProtoMessage() {}
+
+func (x *Vector) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector.ProtoReflect.Descriptor instead.
+func (*Vector) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vector) GetDim() int64 {
+	if x != nil {
+		return x.Dim
+	}
+	return 0
+}
+
+func (x *Vector) GetIndices() []int64 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *Vector) GetValues() []float64 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type UpsertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Vector        *Vector                `protobuf:"bytes,2,opt,name=vector,proto3" json:"vector,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertRequest) Reset() {
+	*x = UpsertRequest{}
+	mi := &file_sparse_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertRequest) ProtoMessage() {}
+
+func (x *UpsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertRequest.ProtoReflect.Descriptor instead.
+func (*UpsertRequest) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpsertRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpsertRequest) GetVector() *Vector {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+type UpsertResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertResponse) Reset() {
+	*x = UpsertResponse{}
+	mi := &file_sparse_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertResponse) ProtoMessage() {}
+
+func (x *UpsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertResponse.ProtoReflect.Descriptor instead.
+func (*UpsertResponse) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{2}
+}
+
+type DeleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRequest) Reset() {
+	*x = DeleteRequest{}
+	mi := &file_sparse_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRequest) ProtoMessage() {}
+
+func (x *DeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRequest) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeleteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteResponse) Reset() {
+	*x = DeleteResponse{}
+	mi := &file_sparse_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteResponse) ProtoMessage() {}
+
+func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
+func (*DeleteResponse) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{4}
+}
+
+type QueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vector        *Vector                `protobuf:"bytes,1,opt,name=vector,proto3" json:"vector,omitempty"`
+	K             int32                  `protobuf:"varint,2,opt,name=k,proto3" json:"k,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_sparse_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *QueryRequest) GetVector() *Vector {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+type Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_sparse_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Match) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Match) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type QueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*Match               `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_sparse_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResponse) ProtoMessage() {}
+
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *QueryResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type SimilarityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             *Vector                `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             *Vector                `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarityRequest) Reset() {
+	*x = SimilarityRequest{}
+	mi := &file_sparse_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarityRequest) ProtoMessage() {}
+
+func (x *SimilarityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarityRequest.ProtoReflect.Descriptor instead.
+func (*SimilarityRequest) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SimilarityRequest) GetA() *Vector {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *SimilarityRequest) GetB() *Vector {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+type SimilarityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Similarity    float64                `protobuf:"fixed64,1,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SimilarityResponse) Reset() {
+	*x = SimilarityResponse{}
+	mi := &file_sparse_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SimilarityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SimilarityResponse) ProtoMessage() {}
+
+func (x *SimilarityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SimilarityResponse.ProtoReflect.Descriptor instead.
+func (*SimilarityResponse) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SimilarityResponse) GetSimilarity() float64 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type ArithmeticRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            ArithmeticRequest_Op   `protobuf:"varint,1,opt,name=op,proto3,enum=sparse.v1.ArithmeticRequest_Op" json:"op,omitempty"`
+	A             *Vector                `protobuf:"bytes,2,opt,name=a,proto3" json:"a,omitempty"`
+	B             *Vector                `protobuf:"bytes,3,opt,name=b,proto3" json:"b,omitempty"`
+	Scalar        float64                `protobuf:"fixed64,4,opt,name=scalar,proto3" json:"scalar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArithmeticRequest) Reset() {
+	*x = ArithmeticRequest{}
+	mi := &file_sparse_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArithmeticRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArithmeticRequest) ProtoMessage() {}
+
+func (x *ArithmeticRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArithmeticRequest.ProtoReflect.Descriptor instead.
+func (*ArithmeticRequest) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ArithmeticRequest) GetOp() ArithmeticRequest_Op {
+	if x != nil {
+		return x.Op
+	}
+	return ArithmeticRequest_OP_UNSPECIFIED
+}
+
+func (x *ArithmeticRequest) GetA() *Vector {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *ArithmeticRequest) GetB() *Vector {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+func (x *ArithmeticRequest) GetScalar() float64 {
+	if x != nil {
+		return x.Scalar
+	}
+	return 0
+}
+
+type ArithmeticResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *Vector                `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArithmeticResponse) Reset() {
+	*x = ArithmeticResponse{}
+	mi := &file_sparse_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArithmeticResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArithmeticResponse) ProtoMessage() {}
+
+func (x *ArithmeticResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_sparse_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArithmeticResponse.ProtoReflect.Descriptor instead.
+func (*ArithmeticResponse) Descriptor() ([]byte, []int) {
+	return file_sparse_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ArithmeticResponse) GetResult() *Vector {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+var File_sparse_proto protoreflect.FileDescriptor
+
+const file_sparse_proto_rawDesc = "" +
+	"\n" +
+	"\fsparse.proto\x12\tsparse.v1\"L\n" +
+	"\x06Vector\x12\x10\n" +
+	"\x03dim\x18\x01 \x01(\x03R\x03dim\x12\x18\n" +
+	"\aindices\x18\x02 \x03(\x03R\aindices\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\x01R\x06values\"J\n" +
+	"\rUpsertRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
+	"\x06vector\x18\x02 \x01(\v2\x11.sparse.v1.VectorR\x06vector\"\x10\n" +
+	"\x0eUpsertResponse\"\x1f\n" +
+	"\rDeleteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x10\n" +
+	"\x0eDeleteResponse\"G\n" +
+	"\fQueryRequest\x12)\n" +
+	"\x06vector\x18\x01 \x01(\v2\x11.sparse.v1.VectorR\x06vector\x12\f\n" +
+	"\x01k\x18\x02 \x01(\x05R\x01k\"-\n" +
+	"\x05Match\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\";\n" +
+	"\rQueryResponse\x12*\n" +
+	"\amatches\x18\x01 \x03(\v2\x10.sparse.v1.MatchR\amatches\"U\n" +
+	"\x11SimilarityRequest\x12\x1f\n" +
+	"\x01a\x18\x01 \x01(\v2\x11.sparse.v1.VectorR\x01a\x12\x1f\n" +
+	"\x01b\x18\x02 \x01(\v2\x11.sparse.v1.VectorR\x01b\"4\n" +
+	"\x12SimilarityResponse\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x01 \x01(\x01R\n" +
+	"similarity\"\xe1\x01\n" +
+	"\x11ArithmeticRequest\x12/\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x1f.sparse.v1.ArithmeticRequest.OpR\x02op\x12\x1f\n" +
+	"\x01a\x18\x02 \x01(\v2\x11.sparse.v1.VectorR\x01a\x12\x1f\n" +
+	"\x01b\x18\x03 \x01(\v2\x11.sparse.v1.VectorR\x01b\x12\x16\n" +
+	"\x06scalar\x18\x04 \x01(\x01R\x06scalar\"A\n" +
+	"\x02Op\x12\x12\n" +
+	"\x0eOP_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06OP_ADD\x10\x01\x12\f\n" +
+	"\bOP_TIMES\x10\x02\x12\r\n" +
+	"\tOP_APPEND\x10\x03\"?\n" +
+	"\x12ArithmeticResponse\x12)\n" +
+	"\x06result\x18\x01 \x01(\v2\x11.sparse.v1.VectorR\x06result2\xdf\x02\n" +
+	"\rVectorService\x12=\n" +
+	"\x06Upsert\x12\x18.sparse.v1.UpsertRequest\x1a\x19.sparse.v1.UpsertResponse\x12=\n" +
+	"\x06Delete\x12\x18.sparse.v1.DeleteRequest\x1a\x19.sparse.v1.DeleteResponse\x12:\n" +
+	"\x05Query\x12\x17.sparse.v1.QueryRequest\x1a\x18.sparse.v1.QueryResponse\x12I\n" +
+	"\n" +
+	"Similarity\x12\x1c.sparse.v1.SimilarityRequest\x1a\x1d.sparse.v1.SimilarityResponse\x12I\n" +
+	"\n" +
+	"Arithmetic\x12\x1c.sparse.v1.ArithmeticRequest\x1a\x1d.sparse.v1.ArithmeticResponseB#Z!github.com/angadn/sparse/sparsepbb\x06proto3"
+
+var (
+	file_sparse_proto_rawDescOnce sync.Once
+	file_sparse_proto_rawDescData []byte
+)
+
+func file_sparse_proto_rawDescGZIP() []byte {
+	file_sparse_proto_rawDescOnce.Do(func() {
+		file_sparse_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_sparse_proto_rawDesc), len(file_sparse_proto_rawDesc)))
+	})
+	return file_sparse_proto_rawDescData
+}
+
+var file_sparse_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sparse_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_sparse_proto_goTypes = []any{
+	(ArithmeticRequest_Op)(0),  // 0: sparse.v1.ArithmeticRequest.Op
+	(*Vector)(nil),             // 1: sparse.v1.Vector
+	(*UpsertRequest)(nil),      // 2: sparse.v1.UpsertRequest
+	(*UpsertResponse)(nil),     // 3: sparse.v1.UpsertResponse
+	(*DeleteRequest)(nil),      // 4: sparse.v1.DeleteRequest
+	(*DeleteResponse)(nil),     // 5: sparse.v1.DeleteResponse
+	(*QueryRequest)(nil),       // 6: sparse.v1.QueryRequest
+	(*Match)(nil),              // 7: sparse.v1.Match
+	(*QueryResponse)(nil),      // 8: sparse.v1.QueryResponse
+	(*SimilarityRequest)(nil),  // 9: sparse.v1.SimilarityRequest
+	(*SimilarityResponse)(nil), // 10: sparse.v1.SimilarityResponse
+	(*ArithmeticRequest)(nil),  // 11: sparse.v1.ArithmeticRequest
+	(*ArithmeticResponse)(nil), // 12: sparse.v1.ArithmeticResponse
+}
+var file_sparse_proto_depIdxs = []int32{
+	1,  // 0: sparse.v1.UpsertRequest.vector:type_name -> sparse.v1.Vector
+	1,  // 1: sparse.v1.QueryRequest.vector:type_name -> sparse.v1.Vector
+	7,  // 2: sparse.v1.QueryResponse.matches:type_name -> sparse.v1.Match
+	1,  // 3: sparse.v1.SimilarityRequest.a:type_name -> sparse.v1.Vector
+	1,  // 4: sparse.v1.SimilarityRequest.b:type_name -> sparse.v1.Vector
+	0,  // 5: sparse.v1.ArithmeticRequest.op:type_name -> sparse.v1.ArithmeticRequest.Op
+	1,  // 6: sparse.v1.ArithmeticRequest.a:type_name -> sparse.v1.Vector
+	1,  // 7: sparse.v1.ArithmeticRequest.b:type_name -> sparse.v1.Vector
+	1,  // 8: sparse.v1.ArithmeticResponse.result:type_name -> sparse.v1.Vector
+	2,  // 9: sparse.v1.VectorService.Upsert:input_type -> sparse.v1.UpsertRequest
+	4,  // 10: sparse.v1.VectorService.Delete:input_type -> sparse.v1.DeleteRequest
+	6,  // 11: sparse.v1.VectorService.Query:input_type -> sparse.v1.QueryRequest
+	9,  // 12: sparse.v1.VectorService.Similarity:input_type -> sparse.v1.SimilarityRequest
+	11, // 13: sparse.v1.VectorService.Arithmetic:input_type -> sparse.v1.ArithmeticRequest
+	3,  // 14: sparse.v1.VectorService.Upsert:output_type -> sparse.v1.UpsertResponse
+	5,  // 15: sparse.v1.VectorService.Delete:output_type -> sparse.v1.DeleteResponse
+	8,  // 16: sparse.v1.VectorService.Query:output_type -> sparse.v1.QueryResponse
+	10, // 17: sparse.v1.VectorService.Similarity:output_type -> sparse.v1.SimilarityResponse
+	12, // 18: sparse.v1.VectorService.Arithmetic:output_type -> sparse.v1.ArithmeticResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_sparse_proto_init() }
+func file_sparse_proto_init() {
+	if File_sparse_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_sparse_proto_rawDesc), len(file_sparse_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_sparse_proto_goTypes,
+		DependencyIndexes: file_sparse_proto_depIdxs,
+		EnumInfos:         file_sparse_proto_enumTypes,
+		MessageInfos:      file_sparse_proto_msgTypes,
+	}.Build()
+	File_sparse_proto = out.File
+	file_sparse_proto_goTypes = nil
+	file_sparse_proto_depIdxs = nil
+}
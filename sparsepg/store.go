@@ -0,0 +1,240 @@
+// Package sparsepg implements a sparse.Store in PostgreSQL, using any
+// database/sql driver for Postgres.
+//
+// Vectors are kept in a table of (id text primary key, vec ...) rows,
+// where vec is either a bytea holding the vector's binary encoding, or
+// a pgvector sparsevec. With sparsevec columns Query is pushed down to
+// the database; otherwise it scores every row in memory.
+package sparsepg
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/angadn/sparse"
+)
+
+// Layout is how vectors are stored in their column.
+type Layout int
+
+const (
+	// Bytea stores the binary encoding of each vector. It is lossless.
+	Bytea Layout = iota
+
+	// SparseVec stores vectors as pgvector sparsevec values, which hold
+	// float4 components, so values lose precision on the way in.
+	SparseVec
+)
+
+// Store is a sparse.Store backed by a PostgreSQL table.
+type Store struct {
+	db     *sql.DB
+	table  string
+	layout Layout
+}
+
+var _ sparse.Store = (*Store)(nil)
+
+// NewStore constructs a Store over table.
+func NewStore(db *sql.DB, table string, layout Layout) *Store {
+	return &Store{
+		db:     db,
+		table:  `"` + strings.ReplaceAll(table, `"`, `""`) + `"`,
+		layout: layout,
+	}
+}
+
+// CreateTable creates the Store's table if it does not exist. The
+// SparseVec layout requires the pgvector extension.
+func (s *Store) CreateTable(ctx context.Context) error {
+	typ := "bytea"
+	if s.layout == SparseVec {
+		typ = "sparsevec"
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (id text PRIMARY KEY, vec %s NOT NULL)", s.table, typ,
+	))
+	return err
+}
+
+// encode converts v for its column.
+func (s *Store) encode(v sparse.Vector) (any, error) {
+	if s.layout == Bytea {
+		return v.MarshalBinary()
+	}
+
+	return formatSparseVec(v), nil
+}
+
+// decode loads a column value into a Vector.
+func (s *Store) decode(col []byte) (sparse.Vector, error) {
+	var v sparse.Vector
+	if s.layout == Bytea {
+		err := v.UnmarshalBinary(col)
+		return v, err
+	}
+
+	return parseSparseVec(string(col))
+}
+
+// Upsert stores v under id.
+func (s *Store) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	col, err := s.encode(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (id, vec) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET vec = EXCLUDED.vec", s.table,
+	), id, col)
+	return err
+}
+
+// Get loads the Vector stored under id.
+func (s *Store) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	var col []byte
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(
+		"SELECT vec FROM %s WHERE id = $1", s.table,
+	), id).Scan(&col)
+	if errors.Is(err, sql.ErrNoRows) {
+		return sparse.Vector{}, sparse.ErrNotFound
+	} else if err != nil {
+		return sparse.Vector{}, err
+	}
+
+	return s.decode(col)
+}
+
+// Delete removes the Vector stored under id.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = $1", s.table), id)
+	return err
+}
+
+// Len is the number of Vectors in the Store.
+func (s *Store) Len(ctx context.Context) (int, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*) FROM %s", s.table)).Scan(&n)
+	return n, err
+}
+
+// Scan streams every row of the table through fn.
+func (s *Store) Scan(ctx context.Context, fn func(id string, v sparse.Vector) bool) error {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf("SELECT id, vec FROM %s", s.table))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id  string
+			col []byte
+		)
+
+		if err := rows.Scan(&id, &col); err != nil {
+			return err
+		}
+
+		v, err := s.decode(col)
+		if err != nil {
+			return fmt.Errorf("sparsepg: row %q: %w", id, err)
+		}
+
+		if !fn(id, v) {
+			break
+		}
+	}
+
+	return rows.Err()
+}
+
+// Query returns the k stored Vectors most similar to q, best first.
+// With the SparseVec layout the ranking is done by pgvector's cosine
+// distance operator, and otherwise falls back to sparse.Query.
+func (s *Store) Query(ctx context.Context, q sparse.Vector, k int) ([]sparse.Match, error) {
+	if s.layout != SparseVec {
+		return sparse.Query(ctx, s, q, k)
+	} else if k < 0 {
+		return nil, fmt.Errorf("%w: negative k %d", sparse.ErrOutOfRange, k)
+	} else if k == 0 {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT id, 1 - (vec <=> $1::sparsevec) FROM %s "+
+			"WHERE (vec <=> $1::sparsevec) <> 'NaN' "+
+			"ORDER BY vec <=> $1::sparsevec LIMIT $2", s.table,
+	), formatSparseVec(q), k)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	var ret []sparse.Match
+	for rows.Next() {
+		var m sparse.Match
+		if err := rows.Scan(&m.ID, &m.Score); err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, m)
+	}
+
+	return ret, rows.Err()
+}
+
+// formatSparseVec formats v as a sparsevec literal, {i:v,...}/dim, whose
+// indices are 1-based.
+func formatSparseVec(v sparse.Vector) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for n, d := range v.SortedNonZeros() {
+		if n >= 0 && n < v.Size() {
+			if sb.Len() > 1 {
+				sb.WriteByte(',')
+			}
+
+			sb.WriteString(strconv.Itoa(n + 1))
+			sb.WriteByte(':')
+			sb.WriteString(strconv.FormatFloat(d, 'g', -1, 32))
+		}
+	}
+
+	sb.WriteString("}/")
+	sb.WriteString(strconv.Itoa(v.Size()))
+	return sb.String()
+}
+
+// parseSparseVec parses a sparsevec literal.
+func parseSparseVec(s string) (sparse.Vector, error) {
+	body, dimStr, ok := strings.Cut(s, "}/")
+	body, ok2 := strings.CutPrefix(body, "{")
+	dim, err := strconv.Atoi(dimStr)
+	if !ok || !ok2 || err != nil || dim < 0 {
+		return sparse.Vector{}, fmt.Errorf("sparsepg: malformed sparsevec %q", s)
+	}
+
+	ret := sparse.NewVector(dim)
+	if body == "" {
+		return ret, nil
+	}
+
+	for _, pair := range strings.Split(body, ",") {
+		idx, val, ok := strings.Cut(pair, ":")
+		n, err1 := strconv.Atoi(idx)
+		d, err2 := strconv.ParseFloat(val, 64)
+		if !ok || err1 != nil || err2 != nil || n < 1 || n > dim {
+			return sparse.Vector{}, fmt.Errorf("sparsepg: malformed sparsevec %q", s)
+		}
+
+		ret.Set(n-1, d)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,96 @@
+package sparseprom
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/angadn/sparse"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Index records the build times of sparse's indexes and the hit rate of
+// the norm cache. It is a sparse.Tracer, timing the AddAll builds of
+// LSHIndex, MinHashIndex and InvertedIndex once installed with
+// sparse.SetTracer, and a prometheus.Collector: register it to export
+// the metrics.
+type Index struct {
+	next   sparse.Tracer
+	build  *prometheus.HistogramVec
+	hits   prometheus.CounterFunc
+	misses prometheus.CounterFunc
+}
+
+var _ sparse.Tracer = (*Index)(nil)
+var _ prometheus.Collector = (*Index)(nil)
+
+// NewIndex returns an Index that passes every operation on to next, if
+// not nil, so that it can be installed alongside another Tracer (e.g. a
+// sparseotel.Tracer). Metric names are prefixed with namespace, and the
+// labels are attached to every metric.
+func NewIndex(next sparse.Tracer, namespace string, labels prometheus.Labels) *Index {
+	return &Index{
+		next: next,
+		build: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   "index",
+			Name:        "build_duration_seconds",
+			Help:        "Duration of index AddAll builds.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"index"}),
+		hits: prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "norm_cache",
+			Name:        "hits_total",
+			Help:        "Norm lookups served from the cache of Vectors with cached norms.",
+			ConstLabels: labels,
+		}, func() float64 {
+			hits, _ := sparse.NormCacheStats()
+			return float64(hits)
+		}),
+		misses: prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "norm_cache",
+			Name:        "misses_total",
+			Help:        "Norm lookups that recomputed the norms of Vectors with cached norms.",
+			ConstLabels: labels,
+		}, func() float64 {
+			_, misses := sparse.NormCacheStats()
+			return float64(misses)
+		}),
+	}
+}
+
+// Start implements sparse.Tracer, timing op if it is an index build.
+func (idx *Index) Start(ctx context.Context, op string) (context.Context, func(err error)) {
+	end := func(error) {}
+	if idx.next != nil {
+		ctx, end = idx.next.Start(ctx, op)
+	}
+
+	index, ok := strings.CutSuffix(op, ".AddAll")
+	if !ok {
+		return ctx, end
+	}
+
+	start := time.Now()
+	return ctx, func(err error) {
+		idx.build.WithLabelValues(index).Observe(time.Since(start).Seconds())
+		end(err)
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (idx *Index) Describe(ch chan<- *prometheus.Desc) {
+	idx.build.Describe(ch)
+	idx.hits.Describe(ch)
+	idx.misses.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (idx *Index) Collect(ch chan<- prometheus.Metric) {
+	idx.build.Collect(ch)
+	idx.hits.Collect(ch)
+	idx.misses.Collect(ch)
+}
@@ -0,0 +1,39 @@
+package sparseprom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/angadn/sparse"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestIndex(t *testing.T) {
+	idx := NewIndex(nil, "test", nil)
+	sparse.SetTracer(idx)
+	defer sparse.SetTracer(nil)
+
+	vs := []sparse.Vector{sparse.NewVectorFromArray([]float64{1, 0, 2}), sparse.NewVectorFromArray([]float64{0, 3, 0})}
+	if err := sparse.NewInvertedIndex(sparse.DotProduct).AddAll(context.Background(), []string{"a", "b"}, vs); err != nil {
+		t.Fatal(err)
+	} else if _, err := sparse.Query(context.Background(), sparse.NewMemoryStore(), vs[0], 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(idx, "test_index_build_duration_seconds"); n != 1 {
+		t.Errorf("got %d build histograms, want 1 for the InvertedIndex alone", n)
+	}
+
+	hits, _ := sparse.NormCacheStats()
+	cached := sparse.NewVectorFromArray([]float64{3, 4}, sparse.WithCachedNorms())
+	cached.CachedMagnitude()
+	cached.CachedMagnitude()
+	if got := testutil.ToFloat64(idx.hits); got != float64(hits+1) {
+		t.Errorf("hits = %g, want %d", got, hits+1)
+	}
+
+	if err := prometheus.NewPedanticRegistry().Register(idx); err != nil {
+		t.Error(err)
+	}
+}
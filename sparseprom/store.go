@@ -0,0 +1,121 @@
+// Package sparseprom exposes Prometheus metrics for sparse Stores,
+// index builds and the norm cache.
+package sparseprom
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/angadn/sparse"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Store wraps a sparse.Store, recording the latency and errors of each
+// operation. It is a prometheus.Collector: register it to export the
+// metrics, together with a gauge of the wrapped store's size.
+type Store struct {
+	store   sparse.Store
+	latency *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+	size    *prometheus.Desc
+}
+
+var _ sparse.Store = (*Store)(nil)
+var _ prometheus.Collector = (*Store)(nil)
+
+// NewStore wraps store. Metric names are prefixed with namespace, and
+// the labels are attached to every metric.
+func NewStore(store sparse.Store, namespace string, labels prometheus.Labels) *Store {
+	return &Store{
+		store: store,
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Subsystem:   "store",
+			Name:        "operation_duration_seconds",
+			Help:        "Latency of Store operations.",
+			ConstLabels: labels,
+			Buckets:     prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"op"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Subsystem:   "store",
+			Name:        "operation_errors_total",
+			Help:        "Store operations that returned an error.",
+			ConstLabels: labels,
+		}, []string{"op"}),
+		size: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "store", "vectors"),
+			"Number of vectors in the Store.",
+			nil, labels,
+		),
+	}
+}
+
+// observe records an operation that started at start.
+func (s *Store) observe(op string, start time.Time, err error) {
+	s.latency.WithLabelValues(op).Observe(time.Since(start).Seconds())
+	if err != nil && !errors.Is(err, sparse.ErrNotFound) {
+		s.errors.WithLabelValues(op).Inc()
+	}
+}
+
+func (s *Store) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	start := time.Now()
+	err := s.store.Upsert(ctx, id, v)
+	s.observe("upsert", start, err)
+	return err
+}
+
+func (s *Store) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	start := time.Now()
+	v, err := s.store.Get(ctx, id)
+	s.observe("get", start, err)
+	return v, err
+}
+
+func (s *Store) Delete(ctx context.Context, id string) error {
+	start := time.Now()
+	err := s.store.Delete(ctx, id)
+	s.observe("delete", start, err)
+	return err
+}
+
+func (s *Store) Len(ctx context.Context) (int, error) {
+	start := time.Now()
+	n, err := s.store.Len(ctx)
+	s.observe("len", start, err)
+	return n, err
+}
+
+func (s *Store) Scan(ctx context.Context, fn func(id string, v sparse.Vector) bool) error {
+	start := time.Now()
+	err := s.store.Scan(ctx, fn)
+	s.observe("scan", start, err)
+	return err
+}
+
+// Query runs sparse.Query against the wrapped store, recording its
+// latency under the "query" op.
+func (s *Store) Query(ctx context.Context, q sparse.Vector, k int) ([]sparse.Match, error) {
+	start := time.Now()
+	matches, err := sparse.Query(ctx, s.store, q, k)
+	s.observe("query", start, err)
+	return matches, err
+}
+
+// Describe implements prometheus.Collector.
+func (s *Store) Describe(ch chan<- *prometheus.Desc) {
+	s.latency.Describe(ch)
+	s.errors.Describe(ch)
+	ch <- s.size
+}
+
+// Collect implements prometheus.Collector.
+func (s *Store) Collect(ch chan<- prometheus.Metric) {
+	s.latency.Collect(ch)
+	s.errors.Collect(ch)
+	if n, err := s.store.Len(context.Background()); err == nil {
+		ch <- prometheus.MustNewConstMetric(s.size, prometheus.GaugeValue, float64(n))
+	}
+}
@@ -0,0 +1,139 @@
+// Package sparseredis implements a sparse.Store persisted in Redis.
+//
+// Each vector is a hash at <prefix>v:<id> holding its dim, NNZ and its
+// binary encoding, and the set <prefix>ids indexes every stored id.
+// Nothing is held in process: vectors are loaded from Redis only when
+// they are read, and Scan pages through the id set.
+package sparseredis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/angadn/sparse"
+	"github.com/redis/go-redis/v9"
+)
+
+// scanPage is how many ids Scan fetches from Redis per round trip.
+const scanPage = 256
+
+// Store is a sparse.Store backed by Redis.
+type Store struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ sparse.Store = (*Store)(nil)
+
+// NewStore constructs a Store that keeps its keys under prefix.
+func NewStore(client redis.UniversalClient, prefix string) *Store {
+	return &Store{client: client, prefix: prefix}
+}
+
+func (s *Store) key(id string) string {
+	return s.prefix + "v:" + id
+}
+
+func (s *Store) idsKey() string {
+	return s.prefix + "ids"
+}
+
+// Upsert stores v under id.
+func (s *Store) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	nnz := 0
+	for range v.NonZeros() {
+		nnz++
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, s.key(id), "dim", v.Size(), "nnz", nnz, "data", data)
+		p.SAdd(ctx, s.idsKey(), id)
+		return nil
+	})
+
+	return err
+}
+
+// Get loads the Vector stored under id.
+func (s *Store) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	data, err := s.client.HGet(ctx, s.key(id), "data").Bytes()
+	if errors.Is(err, redis.Nil) {
+		return sparse.Vector{}, sparse.ErrNotFound
+	} else if err != nil {
+		return sparse.Vector{}, err
+	}
+
+	var v sparse.Vector
+	err = v.UnmarshalBinary(data)
+	return v, err
+}
+
+// Delete removes the Vector stored under id.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	_, err := s.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, s.key(id))
+		p.SRem(ctx, s.idsKey(), id)
+		return nil
+	})
+
+	return err
+}
+
+// Len is the number of Vectors in the Store.
+func (s *Store) Len(ctx context.Context) (int, error) {
+	n, err := s.client.SCard(ctx, s.idsKey()).Result()
+	return int(n), err
+}
+
+// Scan pages through the stored ids, loading each page of Vectors in a
+// single pipelined round trip. Vectors deleted mid-scan are skipped;
+// Redis may report an id more than once if the set changes during the
+// scan.
+func (s *Store) Scan(ctx context.Context, fn func(id string, v sparse.Vector) bool) error {
+	var cursor uint64
+	for {
+		ids, next, err := s.client.SScan(ctx, s.idsKey(), cursor, "", scanPage).Result()
+		if err != nil {
+			return err
+		}
+
+		cmds := make([]*redis.StringCmd, len(ids))
+		_, err = s.client.Pipelined(ctx, func(p redis.Pipeliner) error {
+			for i, id := range ids {
+				cmds[i] = p.HGet(ctx, s.key(id), "data")
+			}
+
+			return nil
+		})
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+
+		for i, cmd := range cmds {
+			data, err := cmd.Bytes()
+			if errors.Is(err, redis.Nil) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			var v sparse.Vector
+			if err := v.UnmarshalBinary(data); err != nil {
+				return err
+			}
+
+			if !fn(ids[i], v) {
+				return nil
+			}
+		}
+
+		if cursor = next; cursor == 0 {
+			return nil
+		}
+	}
+}
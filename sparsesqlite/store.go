@@ -0,0 +1,162 @@
+// Package sparsesqlite implements a sparse.Store in a single SQLite
+// file, using the pure-Go modernc.org/sqlite driver so no C toolchain or
+// server is needed.
+//
+// Vectors live in a vectors table holding each vector's binary encoding
+// as a blob, alongside its dim and NNZ as queryable columns.
+package sparsesqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/angadn/sparse"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS vectors (
+	id   TEXT PRIMARY KEY,
+	dim  INTEGER NOT NULL,
+	nnz  INTEGER NOT NULL,
+	data BLOB NOT NULL
+)`
+
+// Store is a sparse.Store backed by SQLite.
+type Store struct {
+	db *sql.DB
+}
+
+var _ sparse.Store = (*Store)(nil)
+
+// Open opens, creating if needed, the SQLite database at path.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer; serialize access rather than
+	// surface SQLITE_BUSY errors.
+	db.SetMaxOpenConns(1)
+	s, err := NewStore(context.Background(), db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NewStore constructs a Store over an open database, creating its table
+// if needed.
+func NewStore(ctx context.Context, db *sql.DB) (*Store, error) {
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Upsert stores v under id.
+func (s *Store) Upsert(ctx context.Context, id string, v sparse.Vector) error {
+	data, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	nnz := 0
+	for range v.NonZeros() {
+		nnz++
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		"INSERT INTO vectors (id, dim, nnz, data) VALUES (?, ?, ?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET dim = excluded.dim, nnz = excluded.nnz, data = excluded.data",
+		id, v.Size(), nnz, data,
+	)
+	return err
+}
+
+// Get loads the Vector stored under id.
+func (s *Store) Get(ctx context.Context, id string) (sparse.Vector, error) {
+	var (
+		data []byte
+		v    sparse.Vector
+	)
+
+	err := s.db.QueryRowContext(ctx, "SELECT data FROM vectors WHERE id = ?", id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, sparse.ErrNotFound
+	} else if err != nil {
+		return v, err
+	}
+
+	err = v.UnmarshalBinary(data)
+	return v, err
+}
+
+// Delete removes the Vector stored under id.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM vectors WHERE id = ?", id)
+	return err
+}
+
+// Len is the number of Vectors in the Store.
+func (s *Store) Len(ctx context.Context) (int, error) {
+	var n int
+	err := s.db.QueryRowContext(ctx, "SELECT count(*) FROM vectors").Scan(&n)
+	return n, err
+}
+
+// Scan streams every stored Vector through fn, in id order. The rows
+// are read fully before fn is called, since the Store holds a single
+// connection that fn may want to use.
+func (s *Store) Scan(ctx context.Context, fn func(id string, v sparse.Vector) bool) error {
+	rows, err := s.db.QueryContext(ctx, "SELECT id, data FROM vectors ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+	var (
+		ids     []string
+		vectors []sparse.Vector
+	)
+
+	for rows.Next() {
+		var (
+			id   string
+			data []byte
+			v    sparse.Vector
+		)
+
+		if err := rows.Scan(&id, &data); err != nil {
+			return err
+		} else if err := v.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("sparsesqlite: row %q: %w", id, err)
+		}
+
+		ids = append(ids, id)
+		vectors = append(vectors, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows.Close()
+	for i, id := range ids {
+		if !fn(id, vectors[i]) {
+			break
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package sparsetest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+
+	"github.com/angadn/sparse"
+)
+
+// ErrInvariant is returned by the checkers when an invariant does not
+// hold.
+var ErrInvariant = errors.New("sparsetest: invariant violated")
+
+// tolerance is the relative error the checkers allow for floating-point
+// rounding.
+const tolerance = 1e-9
+
+// approx reports whether a and b agree to within tolerance, relative to
+// scale, or are the same infinity or both NaN.
+func approx(a float64, b float64, scale float64) bool {
+	return same(a, b) || math.Abs(a-b) <= tolerance*max(scale, 1)
+}
+
+// CheckDotSymmetry checks that Dot(a, b) == Dot(b, a).
+func CheckDotSymmetry(a sparse.Vector, b sparse.Vector) error {
+	ab, ba := sparse.Dot(a, b), sparse.Dot(b, a)
+	if !approx(ab, ba, a.Magnitude()*b.Magnitude()) {
+		return fmt.Errorf("%w: Dot(a, b) = %g but Dot(b, a) = %g", ErrInvariant, ab, ba)
+	}
+
+	return nil
+}
+
+// CheckAddCommutative checks that Add(a, b) and Add(b, a) have the same
+// values.
+func CheckAddCommutative(a sparse.Vector, b sparse.Vector) error {
+	ab, ba := sparse.Add(a, b), sparse.Add(b, a)
+	if !identical(ab, ba) {
+		return fmt.Errorf("%w: Add(a, b) = %v but Add(b, a) = %v", ErrInvariant, ab, ba)
+	}
+
+	return nil
+}
+
+// CheckTriangle checks the triangle inequality |a + b| <= |a| + |b|.
+func CheckTriangle(a sparse.Vector, b sparse.Vector) error {
+	sum, bound := sparse.Add(a, b).Magnitude(), a.Magnitude()+b.Magnitude()
+	if sum > bound && !approx(sum, bound, bound) {
+		return fmt.Errorf("%w: |a + b| = %g > |a| + |b| = %g", ErrInvariant, sum, bound)
+	}
+
+	return nil
+}
+
+// CheckCauchySchwarz checks that |Dot(a, b)| <= |a| |b|.
+func CheckCauchySchwarz(a sparse.Vector, b sparse.Vector) error {
+	dot, bound := math.Abs(sparse.Dot(a, b)), a.Magnitude()*b.Magnitude()
+	if dot > bound && !approx(dot, bound, bound) {
+		return fmt.Errorf("%w: |Dot(a, b)| = %g > |a| |b| = %g", ErrInvariant, dot, bound)
+	}
+
+	return nil
+}
+
+// CheckRoundTrip checks that v survives binary encoding unchanged,
+// including its dimensionality.
+func CheckRoundTrip(v sparse.Vector) error {
+	b, err := v.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("%w: marshal: %w", ErrInvariant, err)
+	}
+
+	var got sparse.Vector
+	if err := got.UnmarshalBinary(b); err != nil {
+		return fmt.Errorf("%w: unmarshal: %w", ErrInvariant, err)
+	} else if got.Size() != v.Size() || !identical(got, v) {
+		return fmt.Errorf("%w: round trip of %v gave %v", ErrInvariant, v, got)
+	}
+
+	return nil
+}
+
+// CheckJSONRoundTrip checks that v survives JSON encoding unchanged,
+// including its dimensionality. Vectors with NaN or infinite values,
+// which JSON cannot represent, pass trivially.
+func CheckJSONRoundTrip(v sparse.Vector) error {
+	for _, d := range v.NonZeros() {
+		if math.IsNaN(d) || math.IsInf(d, 0) {
+			return nil
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("%w: marshal JSON: %w", ErrInvariant, err)
+	}
+
+	var got sparse.Vector
+	if err := json.Unmarshal(b, &got); err != nil {
+		return fmt.Errorf("%w: unmarshal JSON: %w", ErrInvariant, err)
+	} else if got.Size() != v.Size() || !identical(got, v) {
+		return fmt.Errorf("%w: JSON round trip of %v gave %v", ErrInvariant, v, got)
+	}
+
+	return nil
+}
+
+// CheckTextRoundTrip checks that v survives text encoding, and so
+// Parse, unchanged, including its dimensionality.
+func CheckTextRoundTrip(v sparse.Vector) error {
+	b, err := v.MarshalText()
+	if err != nil {
+		return fmt.Errorf("%w: marshal text: %w", ErrInvariant, err)
+	}
+
+	got, err := sparse.Parse(string(b))
+	if err != nil {
+		return fmt.Errorf("%w: parse %q: %w", ErrInvariant, b, err)
+	} else if got.Size() != v.Size() || !identical(got, v) {
+		return fmt.Errorf("%w: text round trip of %v gave %v", ErrInvariant, v, got)
+	}
+
+	return nil
+}
+
+// CheckCloneIsolation checks that v.Clone() equals v, and that mutating
+// the clone leaves v unchanged.
+func CheckCloneIsolation(v sparse.Vector) error {
+	before := v.ToMap()
+	c := v.Clone()
+	if c.Size() != v.Size() || !identical(c, v) {
+		return fmt.Errorf("%w: Clone of %v gave %v", ErrInvariant, v, c)
+	}
+
+	c.ScaleInPlace(2)
+	c.Clear()
+	if !maps.EqualFunc(before, v.ToMap(), same) {
+		return fmt.Errorf("%w: mutating a Clone changed %v", ErrInvariant, v)
+	}
+
+	return nil
+}
+
+// same reports whether two values are the same, counting NaNs as the
+// same as each other.
+func same(a float64, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+// identical reports whether a and b have the same non-zero entries,
+// counting NaNs as the same as each other unlike Vector.Equals.
+func identical(a sparse.Vector, b sparse.Vector) bool {
+	if a.NNZ() != b.NNZ() {
+		return false
+	}
+
+	for n, d := range a.NonZeros() {
+		if !same(d, b.Get(n)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckAll runs every checker over each pair of vs, returning the
+// errors it finds joined.
+func CheckAll(vs ...sparse.Vector) error {
+	var errs []error
+	for i, a := range vs {
+		errs = append(errs, CheckRoundTrip(a), CheckJSONRoundTrip(a), CheckTextRoundTrip(a), CheckCloneIsolation(a))
+		for _, b := range vs[i:] {
+			errs = append(errs, CheckDotSymmetry(a, b), CheckAddCommutative(a, b), CheckTriangle(a, b), CheckCauchySchwarz(a, b))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,115 @@
+package sparsetest
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+// pairs are pairs of Vectors every pairwise checker should pass.
+var pairs = [][2]sparse.Vector{
+	{sparse.NewVector(0), sparse.NewVector(0)},
+	{sparse.NewVectorFromArray([]float64{1, 0, -2}), sparse.NewVectorFromArray([]float64{0, 3, 4})},
+	{sparse.NewVectorFromArray([]float64{1e300, 0, 1e-300}), sparse.NewVectorFromArray([]float64{-1e300, 5, 0})},
+	{sparse.NewVectorFromArray([]float64{2, 2}), sparse.NewVectorFromArray([]float64{2, 2})},
+}
+
+// singles are Vectors every single-Vector checker should pass.
+var singles = []sparse.Vector{
+	sparse.NewVector(0),
+	sparse.NewVector(1000),
+	sparse.NewVectorFromArray([]float64{0, -0.5, 0, math.MaxFloat64, math.SmallestNonzeroFloat64}),
+	sparse.NewVectorFromArray([]float64{math.NaN(), math.Inf(1), math.Inf(-1)}),
+}
+
+// outOfRange returns a Vector storing an entry outside its dimensions,
+// which none of the encodings accept.
+func outOfRange() sparse.Vector {
+	v := sparse.NewVector(2)
+	v.Set(5, 1)
+	return v
+}
+
+func TestCheckDotSymmetry(t *testing.T) {
+	for _, p := range pairs {
+		if err := CheckDotSymmetry(p[0], p[1]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckAddCommutative(t *testing.T) {
+	for _, p := range pairs {
+		if err := CheckAddCommutative(p[0], p[1]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckTriangle(t *testing.T) {
+	for _, p := range pairs {
+		if err := CheckTriangle(p[0], p[1]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckCauchySchwarz(t *testing.T) {
+	for _, p := range pairs {
+		if err := CheckCauchySchwarz(p[0], p[1]); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	for _, v := range singles {
+		if err := CheckRoundTrip(v); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if err := CheckRoundTrip(outOfRange()); !errors.Is(err, ErrInvariant) {
+		t.Errorf("got %v for an out-of-range entry, want ErrInvariant", err)
+	}
+}
+
+func TestCheckJSONRoundTrip(t *testing.T) {
+	for _, v := range singles {
+		if err := CheckJSONRoundTrip(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckTextRoundTrip(t *testing.T) {
+	for _, v := range singles {
+		if err := CheckTextRoundTrip(v); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if err := CheckTextRoundTrip(outOfRange()); !errors.Is(err, ErrInvariant) {
+		t.Errorf("got %v for an out-of-range entry, want ErrInvariant", err)
+	}
+}
+
+func TestCheckCloneIsolation(t *testing.T) {
+	for _, v := range singles {
+		if err := CheckCloneIsolation(v); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestCheckAll(t *testing.T) {
+	if err := CheckAll(pairs[1][0], pairs[1][1], pairs[2][0]); err != nil {
+		t.Error(err)
+	}
+
+	if err := CheckAll(pairs[1][0], outOfRange()); !errors.Is(err, ErrInvariant) {
+		t.Errorf("got %v with an out-of-range entry, want ErrInvariant", err)
+	}
+}
@@ -0,0 +1,243 @@
+package sparsetest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/angadn/sparse"
+)
+
+// maxFuzzDim bounds the dimensionality of Vectors decoded from fuzz
+// input, so dense operations stay cheap.
+const maxFuzzDim = 1 << 16
+
+// AddCorpus seeds f with the binary encodings of vs, for fuzz targets
+// that decode their input with FromBytes or Vector.UnmarshalBinary.
+func AddCorpus(f *testing.F, vs ...sparse.Vector) {
+	for _, v := range vs {
+		b, err := v.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(b)
+	}
+}
+
+// FromBytes decodes arbitrary fuzz input into a Vector. Input that is a
+// valid binary encoding decodes as such; anything else is read as a
+// uint16 dimensionality followed by (uint16 index, float64 value)
+// pairs, so every input yields some Vector.
+func FromBytes(b []byte) sparse.Vector {
+	var ret sparse.Vector
+	if err := ret.UnmarshalBinary(b); err == nil && ret.Size() <= maxFuzzDim {
+		return ret
+	}
+
+	if len(b) < 2 {
+		return sparse.NewVector(0)
+	}
+
+	ret = sparse.NewVector(int(binary.LittleEndian.Uint16(b)) + 1)
+	for b = b[2:]; len(b) >= 10; b = b[10:] {
+		n := int(binary.LittleEndian.Uint16(b)) % ret.Size()
+		ret.Set(n, math.Float64frombits(binary.LittleEndian.Uint64(b[2:])))
+	}
+
+	return ret
+}
+
+// FuzzBinary fuzzes Vector.UnmarshalBinary from the encodings of seeds,
+// checking that every input it accepts survives a round trip. Call it
+// from a fuzz test:
+//
+//	func FuzzBinary(f *testing.F) { sparsetest.FuzzBinary(f, seeds...) }
+func FuzzBinary(f *testing.F, seeds ...sparse.Vector) {
+	AddCorpus(f, seeds...)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var v sparse.Vector
+		if v.UnmarshalBinary(b) != nil {
+			return
+		}
+
+		enc, err := v.MarshalBinary()
+		if err != nil {
+			t.Fatalf("re-encoding %v: %v", v, err)
+		}
+
+		var got sparse.Vector
+		if err := got.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("re-decoding %x: %v", enc, err)
+		} else if got.Size() != v.Size() || !identical(got, v) {
+			t.Fatalf("%v re-decoded as %v", v, got)
+		}
+	})
+}
+
+// FuzzJSON fuzzes Vector.UnmarshalJSON from the JSON of seeds, checking
+// that every input it accepts survives a round trip.
+func FuzzJSON(f *testing.F, seeds ...sparse.Vector) {
+	for _, v := range seeds {
+		b, err := json.Marshal(v)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var v sparse.Vector
+		if json.Unmarshal(b, &v) != nil {
+			return
+		}
+
+		if err := CheckJSONRoundTrip(v); err != nil {
+			t.Fatalf("%s: %v", b, err)
+		}
+	})
+}
+
+// FuzzText fuzzes Parse from the text encodings of seeds, checking that
+// every input it accepts survives a round trip.
+func FuzzText(f *testing.F, seeds ...sparse.Vector) {
+	for _, v := range seeds {
+		b, err := v.MarshalText()
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(string(b))
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		v, err := sparse.Parse(s)
+		if err != nil {
+			return
+		}
+
+		if err := CheckTextRoundTrip(v); err != nil {
+			t.Fatalf("%q: %v", s, err)
+		}
+	})
+}
+
+// FuzzDecoder fuzzes a Decoder over streams of seeds, checking that the
+// Vectors it decodes survive being encoded and decoded again.
+func FuzzDecoder(f *testing.F, seeds ...sparse.Vector) {
+	var buf bytes.Buffer
+	enc := sparse.NewEncoder(&buf)
+	for _, v := range seeds {
+		if err := enc.Encode(v); err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(bytes.Clone(buf.Bytes()))
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var decoded []sparse.Vector
+		for v, err := range sparse.NewDecoder(bytes.NewReader(b)).All() {
+			if err != nil {
+				break
+			}
+
+			decoded = append(decoded, v)
+		}
+
+		var out bytes.Buffer
+		enc := sparse.NewEncoder(&out)
+		for _, v := range decoded {
+			if err := enc.Encode(v); err != nil {
+				t.Fatalf("re-encoding %v: %v", v, err)
+			}
+		}
+
+		i := 0
+		for v, err := range sparse.NewDecoder(&out).All() {
+			if err != nil {
+				t.Fatalf("re-decoding: %v", err)
+			} else if !identical(v, decoded[i]) || v.Size() != decoded[i].Size() {
+				t.Fatalf("%v re-decoded as %v", decoded[i], v)
+			}
+
+			i++
+		}
+	})
+}
+
+// FuzzLibSVM fuzzes ReadLibSVM from the libsvm encoding of seeds,
+// checking that every input it accepts survives being written and read
+// again, up to the dimensionality of its rows.
+func FuzzLibSVM(f *testing.F, seeds ...sparse.Vector) {
+	var buf bytes.Buffer
+	if err := sparse.WriteLibSVM(&buf, nil, seeds); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(buf.String())
+	f.Fuzz(func(t *testing.T, s string) {
+		labels, rows, err := sparse.ReadLibSVM(strings.NewReader(s))
+		if err != nil {
+			return
+		}
+
+		var out bytes.Buffer
+		if err := sparse.WriteLibSVM(&out, labels, rows); err != nil {
+			t.Fatalf("writing: %v", err)
+		}
+
+		gotLabels, got, err := sparse.ReadLibSVM(&out)
+		if err != nil {
+			t.Fatalf("re-reading %q: %v", out.String(), err)
+		} else if len(got) != len(rows) {
+			t.Fatalf("%d rows re-read as %d", len(rows), len(got))
+		}
+
+		for i := range rows {
+			if !same(labels[i], gotLabels[i]) || !identical(rows[i], got[i]) {
+				t.Fatalf("row %d: %g %v re-read as %g %v", i, labels[i], rows[i], gotLabels[i], got[i])
+			}
+		}
+	})
+}
+
+// FuzzCSV fuzzes FromCSV on wide files from the CSV encoding of seeds,
+// checking that every input it accepts survives being written and read
+// again.
+func FuzzCSV(f *testing.F, seeds ...sparse.Vector) {
+	var buf bytes.Buffer
+	if err := sparse.WriteCSV(&buf, seeds, sparse.CSVOptions{}); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add(buf.String())
+	f.Fuzz(func(t *testing.T, s string) {
+		rows, err := sparse.FromCSV(strings.NewReader(s), sparse.CSVOptions{})
+		if err != nil {
+			return
+		}
+
+		var out bytes.Buffer
+		if err := sparse.WriteCSV(&out, rows, sparse.CSVOptions{}); err != nil {
+			t.Fatalf("writing: %v", err)
+		}
+
+		got, err := sparse.FromCSV(&out, sparse.CSVOptions{})
+		if err != nil {
+			t.Fatalf("re-reading %q: %v", out.String(), err)
+		} else if len(got) != len(rows) {
+			t.Fatalf("%d rows re-read as %d", len(rows), len(got))
+		}
+
+		for i := range rows {
+			if got[i].Size() != rows[i].Size() || !identical(rows[i], got[i]) {
+				t.Fatalf("row %d: %v re-read as %v", i, rows[i], got[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// Package sparsetest provides helpers for testing code built on sparse:
+// random Vector generators, checkers for the invariants sparse
+// operations should satisfy, and adapters and targets for Go fuzzing of
+// its parsers and codecs.
+package sparsetest
+
+import (
+	"math/rand/v2"
+
+	"github.com/angadn/sparse"
+)
+
+// Generator produces random Vectors.
+type Generator struct {
+	// Dim is the dimensionality of generated Vectors.
+	Dim int
+
+	// Density is the expected fraction of dimensions that are non-zero.
+	Density float64
+
+	// Value draws the value of each non-zero dimension. If it is nil,
+	// values are drawn from the standard normal distribution.
+	Value func(r *rand.Rand) float64
+}
+
+// Vector generates a random Vector from r.
+func (g Generator) Vector(r *rand.Rand) sparse.Vector {
+	value := g.Value
+	if value == nil {
+		value = (*rand.Rand).NormFloat64
+	}
+
+	ret := sparse.NewVector(g.Dim)
+	for n := range g.Dim {
+		if r.Float64() < g.Density {
+			if d := value(r); d != 0 {
+				ret.Set(n, d)
+			}
+		}
+	}
+
+	return ret
+}
+
+// Vectors generates k random Vectors from r.
+func (g Generator) Vectors(r *rand.Rand, k int) []sparse.Vector {
+	ret := make([]sparse.Vector, k)
+	for i := range ret {
+		ret[i] = g.Vector(r)
+	}
+
+	return ret
+}
+
+// Uniform draws values uniformly from [lo, hi).
+func Uniform(lo float64, hi float64) func(r *rand.Rand) float64 {
+	return func(r *rand.Rand) float64 {
+		return lo + (hi-lo)*r.Float64()
+	}
+}
+
+// Integers draws integer values uniformly from [lo, hi), whose
+// arithmetic is exact.
+func Integers(lo int, hi int) func(r *rand.Rand) float64 {
+	return func(r *rand.Rand) float64 {
+		return float64(lo + r.IntN(hi-lo))
+	}
+}
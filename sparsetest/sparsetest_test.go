@@ -0,0 +1,33 @@
+package sparsetest
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestCheckAllGenerated(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, g := range []Generator{
+		{Dim: 0},
+		{Dim: 50, Density: 0},
+		{Dim: 50, Density: 0.1},
+		{Dim: 50, Density: 1},
+		{Dim: 1000, Density: 0.01, Value: Uniform(-1e6, 1e6)},
+		{Dim: 200, Density: 0.2, Value: Integers(-10, 10)},
+	} {
+		vs := g.Vectors(r, 8)
+		if len(vs) != 8 {
+			t.Fatalf("%+v: Vectors generated %d vectors, want 8", g, len(vs))
+		}
+
+		for _, v := range vs {
+			if v.Size() != g.Dim {
+				t.Fatalf("%+v: generated a Vector of dim %d", g, v.Size())
+			}
+		}
+
+		if err := CheckAll(vs...); err != nil {
+			t.Errorf("%+v: %v", g, err)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Package sparsetext turns tokenized documents into sparse Vectors: a
+// bag-of-words Vectorizer with TF-IDF weighting that is fitted to a
+// corpus and then applied to new documents.
+package sparsetext
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/angadn/sparse"
+)
+
+// Vectorizer maps documents to Vectors with one dimension per term of a
+// vocabulary learned by Fit. A zero Vectorizer weights terms by TF-IDF
+// and normalizes each Vector to unit length; the exported fields adjust
+// this and must be set before Fit.
+type Vectorizer struct {
+	// MinDF leaves out of the vocabulary terms that appear in fewer
+	// than MinDF documents.
+	MinDF int
+
+	// Sublinear replaces each term count c with 1 + ln(c).
+	Sublinear bool
+
+	// NoIDF disables IDF weighting, producing term frequencies.
+	NoIDF bool
+
+	// NoNormalize leaves Vectors unnormalized rather than scaling them
+	// to unit Euclidean length.
+	NoNormalize bool
+
+	terms []string
+	index map[string]int
+	df    []int
+	docs  int
+}
+
+// Tokenize splits s into lower-case tokens at every rune that is not a
+// letter or digit.
+func Tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Fit learns the vocabulary and document frequencies of docs, replacing
+// any learned before. Terms are numbered in lexical order, so fitting
+// the same corpus always yields the same dimensions.
+func (vz *Vectorizer) Fit(docs [][]string) {
+	counts := map[string]int{}
+	for _, doc := range docs {
+		seen := map[string]bool{}
+		for _, t := range doc {
+			if !seen[t] {
+				seen[t] = true
+				counts[t]++
+			}
+		}
+	}
+
+	vz.terms = vz.terms[:0]
+	for t, c := range counts {
+		if c >= vz.MinDF {
+			vz.terms = append(vz.terms, t)
+		}
+	}
+
+	slices.Sort(vz.terms)
+	vz.reindex()
+	vz.df = make([]int, len(vz.terms))
+	for n, t := range vz.terms {
+		vz.df[n] = counts[t]
+	}
+
+	vz.docs = len(docs)
+}
+
+// reindex rebuilds the term-to-dimension index from the vocabulary.
+func (vz *Vectorizer) reindex() {
+	vz.index = make(map[string]int, len(vz.terms))
+	for n, t := range vz.terms {
+		vz.index[t] = n
+	}
+}
+
+// FitTransform fits the Vectorizer to docs and transforms each of them.
+func (vz *Vectorizer) FitTransform(docs [][]string) []sparse.Vector {
+	vz.Fit(docs)
+	ret := make([]sparse.Vector, len(docs))
+	for i, doc := range docs {
+		ret[i] = vz.Transform(doc)
+	}
+
+	return ret
+}
+
+// Len is the size of the vocabulary, and the dimensionality of the
+// Vectors the Vectorizer produces.
+func (vz *Vectorizer) Len() int {
+	return len(vz.terms)
+}
+
+// Vocabulary returns the learned terms, indexed by dimension.
+func (vz *Vectorizer) Vocabulary() []string {
+	return slices.Clone(vz.terms)
+}
+
+// Index returns the dimension of term, reporting whether it is in the
+// vocabulary.
+func (vz *Vectorizer) Index(term string) (int, bool) {
+	n, ok := vz.index[term]
+	return n, ok
+}
+
+// IDF is the inverse document frequency of the n'th term, smoothed as
+// ln((1 + docs) / (1 + df)) + 1 so that no term is weighted zero. It is
+// 1 for every term if NoIDF is set.
+func (vz *Vectorizer) IDF(n int) float64 {
+	if vz.NoIDF {
+		return 1
+	}
+
+	return math.Log(float64(1+vz.docs)/float64(1+vz.df[n])) + 1
+}
+
+// Counts returns the bag-of-words Vector of doc: the number of times each
+// vocabulary term occurs in it. Terms outside the vocabulary are
+// ignored.
+func (vz *Vectorizer) Counts(doc []string) sparse.Vector {
+	ret := sparse.NewVector(len(vz.terms))
+	for _, t := range doc {
+		if n, ok := vz.index[t]; ok {
+			ret.Set(n, ret.Get(n)+1)
+		}
+	}
+
+	return ret
+}
+
+// Transform returns the weighted Vector of doc. Terms outside the
+// vocabulary are ignored.
+func (vz *Vectorizer) Transform(doc []string) sparse.Vector {
+	ret := vz.Counts(doc)
+	for n, c := range ret.NonZeros() {
+		if vz.Sublinear {
+			c = 1 + math.Log(c)
+		}
+
+		ret.Set(n, c*vz.IDF(n))
+	}
+
+	if vz.NoNormalize {
+		return ret
+	}
+
+	return ret.Normalize(2)
+}
+
+// vectorizerJSON is the JSON form of a fitted Vectorizer.
+type vectorizerJSON struct {
+	MinDF       int      `json:"min_df,omitempty"`
+	Sublinear   bool     `json:"sublinear,omitempty"`
+	NoIDF       bool     `json:"no_idf,omitempty"`
+	NoNormalize bool     `json:"no_normalize,omitempty"`
+	Terms       []string `json:"terms"`
+	DF          []int    `json:"df"`
+	Docs        int      `json:"docs"`
+}
+
+// MarshalJSON implements json.Marshaler, persisting the Vectorizer's
+// settings, vocabulary and document frequencies.
+func (vz *Vectorizer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(vectorizerJSON{
+		MinDF:       vz.MinDF,
+		Sublinear:   vz.Sublinear,
+		NoIDF:       vz.NoIDF,
+		NoNormalize: vz.NoNormalize,
+		Terms:       vz.terms,
+		DF:          vz.df,
+		Docs:        vz.docs,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, restoring a Vectorizer
+// persisted by MarshalJSON.
+func (vz *Vectorizer) UnmarshalJSON(b []byte) error {
+	var j vectorizerJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+
+	if len(j.Terms) != len(j.DF) {
+		return errors.New("sparsetext: vocabulary and document frequencies differ in length")
+	}
+
+	*vz = Vectorizer{
+		MinDF:       j.MinDF,
+		Sublinear:   j.Sublinear,
+		NoIDF:       j.NoIDF,
+		NoNormalize: j.NoNormalize,
+		terms:       j.Terms,
+		df:          j.DF,
+		docs:        j.Docs,
+	}
+
+	vz.reindex()
+	return nil
+}
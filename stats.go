@@ -0,0 +1,318 @@
+package sparse
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+)
+
+// Histogram counts the Vector's stored values into the bins delimited by
+// the ascending edges given, so len(bins)-1 counts are returned. Each bin
+// includes its lower edge, and the last its upper edge as well. Values
+// outside the edges are not counted.
+func (v Vector) Histogram(bins []float64) []int {
+	if len(bins) < 2 {
+		return []int{}
+	}
+
+	ret := make([]int, len(bins)-1)
+	last := bins[len(bins)-1]
+	for _, d := range v.data {
+		if d < bins[0] || d > last || d != d {
+			continue
+		} else if d == last {
+			ret[len(ret)-1]++
+			continue
+		}
+
+		ret[sort.Search(len(bins), func(i int) bool { return bins[i] > d })-1]++
+	}
+
+	return ret
+}
+
+// Summary describes the distribution of a Vector's stored values.
+type Summary struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+
+	sketch quantileSketch
+}
+
+// Quantile estimates the q'th quantile of the stored values, for q in
+// [0, 1], interpolating between stored values. Vectors of up to a
+// hundred entries are summarized without approximation.
+func (s Summary) Quantile(q float64) float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+
+	return s.sketch.quantile(min(max(q, 0), 1), s.Min, s.Max)
+}
+
+// Summary summarizes the Vector's stored values. NaNs are ignored.
+func (v Vector) Summary() Summary {
+	ret := Summary{Min: math.Inf(1), Max: math.Inf(-1)}
+	ret.sketch.size = 100
+	sum := float64(0)
+	for _, d := range v.data {
+		if d != d {
+			continue
+		}
+
+		ret.Count++
+		ret.Min, ret.Max = min(ret.Min, d), max(ret.Max, d)
+		sum += d
+		ret.sketch.add(d)
+	}
+
+	if ret.Count == 0 {
+		return Summary{Min: math.NaN(), Max: math.NaN(), Mean: math.NaN()}
+	}
+
+	ret.Mean = sum / float64(ret.Count)
+	ret.sketch.compress()
+	return ret
+}
+
+// quantileSketch is a streaming quantile sketch that merges values into
+// at most size centroids of roughly equal weight.
+type quantileSketch struct {
+	size      int
+	centroids []centroid
+	buf       []float64
+}
+
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+func (s *quantileSketch) add(d float64) {
+	s.buf = append(s.buf, d)
+	if len(s.buf) >= s.size {
+		s.compress()
+	}
+}
+
+// compress merges buffered values into the centroids.
+func (s *quantileSketch) compress() {
+	if len(s.buf) == 0 {
+		return
+	}
+
+	all := s.centroids
+	total := float64(0)
+	for _, c := range all {
+		total += c.weight
+	}
+
+	for _, d := range s.buf {
+		all = append(all, centroid{d, 1})
+	}
+
+	total += float64(len(s.buf))
+	s.buf = s.buf[:0]
+	slices.SortFunc(all, func(a, b centroid) int {
+		return cmp.Compare(a.mean, b.mean)
+	})
+
+	limit := max(total/float64(s.size), 1)
+	merged := all[:1]
+	for _, c := range all[1:] {
+		last := &merged[len(merged)-1]
+		if last.weight+c.weight <= limit {
+			w := last.weight + c.weight
+			last.mean += (c.mean - last.mean) * c.weight / w
+			last.weight = w
+		} else {
+			merged = append(merged, c)
+		}
+	}
+
+	s.centroids = slices.Clip(slices.Clone(merged))
+}
+
+// quantile interpolates between the centroids on either side of the
+// q'th fraction of the total weight, or the bounds lo and hi of the
+// values beyond the outermost centroids.
+func (s *quantileSketch) quantile(q float64, lo float64, hi float64) float64 {
+	total := float64(0)
+	for _, c := range s.centroids {
+		total += c.weight
+	}
+
+	target := q * total
+	cum := float64(0)
+	prev, prevAt := lo, float64(0)
+	for _, c := range append(s.centroids, centroid{hi, 0}) {
+		at := cum + c.weight/2
+		if target <= at {
+			if at == prevAt {
+				return c.mean
+			}
+
+			return prev + (c.mean-prev)*(target-prevAt)/(at-prevAt)
+		}
+
+		prev, prevAt = c.mean, at
+		cum += c.weight
+	}
+
+	return hi
+}
+
+// Sum of the Vector's values. Like the other statistics over its
+// dimensions, it leaves out entries stored outside them.
+func (v Vector) Sum() float64 {
+	ret := float64(0)
+	for n, d := range v.data {
+		if n >= 0 && n < v.dim {
+			ret += d
+		}
+	}
+
+	return ret
+}
+
+// Mean of the Vector's values over all its dimensions, implicit zeros
+// included, or NaN for a zero-dimensional Vector.
+func (v Vector) Mean() float64 {
+	return v.Sum() / float64(v.dim)
+}
+
+// Variance is the population variance of the Vector's values over all
+// its dimensions, implicit zeros included, or NaN for a
+// zero-dimensional Vector.
+func (v Vector) Variance() float64 {
+	mean := v.Mean()
+	sq := float64(0)
+	stored := 0
+	for n, d := range v.data {
+		if n >= 0 && n < v.dim {
+			sq += (d - mean) * (d - mean)
+			stored++
+		}
+	}
+
+	return (sq + float64(v.dim-stored)*mean*mean) / float64(v.dim)
+}
+
+// Covariance is the population covariance of two Vectors' values over
+// all their dimensions, implicit zeros included, or NaN if both are
+// zero-dimensional. Covariance does not check that their
+// dimensionalities match, treating the shorter Vector's missing
+// dimensions as zero; see CovarianceE for a checked variant.
+func Covariance(v1 Vector, v2 Vector) float64 {
+	dim := max(v1.dim, v2.dim)
+	mean1, mean2 := v1.Sum()/float64(dim), v2.Sum()/float64(dim)
+	sum, stored := float64(0), 0
+	for n, d := range v1.data {
+		if n >= 0 && n < v1.dim {
+			sum += (d - mean1) * (v2.data[n] - mean2)
+			stored++
+		}
+	}
+
+	for n, d := range v2.data {
+		if _, ok := v1.data[n]; !ok && n >= 0 && n < v2.dim {
+			sum += -mean1 * (d - mean2)
+			stored++
+		}
+	}
+
+	// Dimensions neither Vector stores each contribute the product of
+	// the means.
+	return (sum + float64(dim-stored)*mean1*mean2) / float64(dim)
+}
+
+// Correlation is the Pearson correlation coefficient of two Vectors'
+// values over all their dimensions, implicit zeros included, clamped to
+// [-1, 1] against rounding. It is NaN if either Vector's values are
+// constant. Like Covariance, it treats the shorter Vector's missing
+// dimensions as zero; see CorrelationE for a checked variant.
+func Correlation(v1 Vector, v2 Vector) float64 {
+	return max(-1, min(1, Covariance(v1, v2)/math.Sqrt(Covariance(v1, v1)*Covariance(v2, v2))))
+}
+
+// firstImplicitZero is the lowest dimension the Vector does not store,
+// or -1 if it stores them all.
+func (v Vector) firstImplicitZero() int {
+	for n := range v.dim {
+		if _, ok := v.data[n]; !ok {
+			return n
+		}
+	}
+
+	return -1
+}
+
+// extreme finds the index and value of the Vector's smallest value, or
+// largest if largest is set, implicit zeros included. Ties go to the
+// lowest index. A NaN beats any other value, and the lowest-indexed NaN
+// is reported, so the result does not depend on iteration order. It
+// returns -1 and NaN for a zero-dimensional Vector.
+func (v Vector) extreme(largest bool) (int, float64) {
+	better := func(a, b float64) bool {
+		if largest {
+			return a > b
+		}
+
+		return a < b
+	}
+
+	arg, best := -1, math.NaN()
+	if zero := v.firstImplicitZero(); zero >= 0 {
+		arg, best = zero, 0
+	}
+
+	for n, d := range v.data {
+		if n < 0 || n >= v.dim {
+			continue
+		}
+
+		switch {
+		case arg >= 0 && best != best:
+			if d != d && n < arg {
+				arg = n
+			}
+		case arg < 0 || d != d || better(d, best) || (d == best && n < arg):
+			arg, best = n, d
+		}
+	}
+
+	return arg, best
+}
+
+// Min is the smallest of the Vector's values, implicit zeros included,
+// or NaN for a zero-dimensional Vector or one storing a NaN.
+func (v Vector) Min() float64 {
+	_, d := v.extreme(false)
+	return d
+}
+
+// Max is the largest of the Vector's values, implicit zeros included, or
+// NaN for a zero-dimensional Vector or one storing a NaN.
+func (v Vector) Max() float64 {
+	_, d := v.extreme(true)
+	return d
+}
+
+// ArgMin is the index of the Vector's smallest value, implicit zeros
+// included, preferring the lowest index on ties, or of its lowest NaN.
+// It is -1 for a zero-dimensional Vector.
+func (v Vector) ArgMin() int {
+	n, _ := v.extreme(false)
+	return n
+}
+
+// ArgMax is the index of the Vector's largest value, implicit zeros
+// included, preferring the lowest index on ties, or of its lowest NaN.
+// It is -1 for a zero-dimensional Vector.
+func (v Vector) ArgMax() int {
+	n, _ := v.extreme(true)
+	return n
+}
@@ -0,0 +1,46 @@
+package sparse
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStatsIgnoreOutOfRange(t *testing.T) {
+	v := NewVectorFromArray([]float64{1, 2, 3})
+	v.Set(10, 100) // Lenient: stored outside the dimensions
+
+	if got := v.Sum(); got != 6 {
+		t.Errorf("Sum() = %g, want 6", got)
+	}
+
+	if got := v.Mean(); got != 2 {
+		t.Errorf("Mean() = %g, want 2", got)
+	}
+
+	if got := v.Variance(); math.Abs(got-2.0/3) > 1e-12 {
+		t.Errorf("Variance() = %g, want 2/3", got)
+	}
+
+	if got := v.Max(); got != 3 {
+		t.Errorf("Max() = %g, want 3", got)
+	}
+}
+
+func TestExtremeNaN(t *testing.T) {
+	for range 20 { // map order varies between runs
+		v := NewVectorFromArray([]float64{-5, 0, 7, 0})
+		v.Set(3, math.NaN())
+		v.Set(1, math.NaN())
+
+		if n, d := v.extreme(false); n != 1 || !math.IsNaN(d) {
+			t.Fatalf("extreme(false) = %d, %g, want 1, NaN", n, d)
+		} else if v.ArgMax() != 1 || !math.IsNaN(v.Max()) {
+			t.Fatalf("ArgMax(), Max() = %d, %g, want 1, NaN", v.ArgMax(), v.Max())
+		}
+	}
+
+	v := NewVectorFromArray([]float64{-5, 0, 7})
+	if v.ArgMin() != 0 || v.Min() != -5 || v.ArgMax() != 2 || v.Max() != 7 {
+		t.Fatalf("ArgMin, Min, ArgMax, Max = %d, %g, %d, %g", v.ArgMin(), v.Min(), v.ArgMax(), v.Max())
+	}
+}
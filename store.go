@@ -0,0 +1,183 @@
+package sparse
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// ErrNotFound is returned when a Store holds no Vector under an id.
+var ErrNotFound = errors.New("sparse: vector not found")
+
+// Store is a keyed collection of Vectors. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// Upsert stores v under id, replacing any existing Vector.
+	Upsert(ctx context.Context, id string, v Vector) error
+
+	// Get fetches the Vector stored under id, or ErrNotFound.
+	Get(ctx context.Context, id string) (Vector, error)
+
+	// Delete removes the Vector stored under id. Deleting a missing id
+	// is not an error.
+	Delete(ctx context.Context, id string) error
+
+	// Len is the number of Vectors in the Store.
+	Len(ctx context.Context) (int, error)
+
+	// Scan calls fn for every Vector in the Store, in no particular
+	// order, until fn returns false. fn must not modify v.
+	Scan(ctx context.Context, fn func(id string, v Vector) bool) error
+}
+
+// MemoryStore is an in-memory Store.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	vectors map[string]Vector
+}
+
+// NewMemoryStore constructs an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		vectors: map[string]Vector{},
+	}
+}
+
+// Upsert stores a copy of v under id.
+func (s *MemoryStore) Upsert(ctx context.Context, id string, v Vector) error {
+	v = v.Clone()
+	s.mu.Lock()
+	s.vectors[id] = v
+	s.mu.Unlock()
+	return nil
+}
+
+// Get returns a copy of the Vector stored under id.
+func (s *MemoryStore) Get(ctx context.Context, id string) (Vector, error) {
+	s.mu.RLock()
+	v, ok := s.vectors[id]
+	s.mu.RUnlock()
+	if !ok {
+		return Vector{}, ErrNotFound
+	}
+
+	return v.Clone(), nil
+}
+
+// Delete removes the Vector stored under id.
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	delete(s.vectors, id)
+	s.mu.Unlock()
+	return nil
+}
+
+// Len is the number of Vectors in the MemoryStore.
+func (s *MemoryStore) Len(ctx context.Context) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.vectors), nil
+}
+
+// Scan calls fn over a snapshot of the MemoryStore, so fn may safely
+// call back into the store.
+func (s *MemoryStore) Scan(ctx context.Context, fn func(id string, v Vector) bool) error {
+	s.mu.RLock()
+	ids := make([]string, 0, len(s.vectors))
+	vectors := make([]Vector, 0, len(s.vectors))
+	for id, v := range s.vectors {
+		ids = append(ids, id)
+		vectors = append(vectors, v)
+	}
+	s.mu.RUnlock()
+
+	for i, id := range ids {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !fn(id, vectors[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Match is a scored result of a Query.
+type Match struct {
+	ID    string
+	Score float64
+}
+
+// matchHeap is a min-heap of Matches by Score, used to keep the
+// best k results seen so far.
+type matchHeap []Match
+
+func (h matchHeap) Len() int           { return len(h) }
+func (h matchHeap) Less(i, j int) bool { return h[i].Score < h[j].Score }
+func (h matchHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *matchHeap) Push(x any)        { *h = append(*h, x.(Match)) }
+func (h *matchHeap) Pop() any {
+	old := *h
+	m := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return m
+}
+
+// Query returns the k Vectors in s most similar to q, best first.
+// Vectors whose Similarity to q is undefined (e.g. zero vectors) are
+// skipped. If ctx is done before the scan completes, Query returns the
+// best matches among the Vectors scanned so far, along with ctx's
+// error. It returns ErrOutOfRange for a negative k.
+func Query(ctx context.Context, s Store, q Vector, k int) ([]Match, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("%w: negative k %d", ErrOutOfRange, k)
+	} else if k == 0 {
+		return nil, nil
+	}
+
+	var ret []Match
+	err := instrument(ctx, "Query", func(ctx context.Context) error {
+		size, err := s.Len(ctx)
+		if err != nil {
+			return err
+		}
+
+		h := make(matchHeap, 0, min(k, size))
+		err = s.Scan(ctx, func(id string, v Vector) bool {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			score := Similarity(q, v)
+			if math.IsNaN(score) {
+				return true
+			}
+
+			if len(h) < k {
+				heap.Push(&h, Match{ID: id, Score: score})
+			} else if score > h[0].Score {
+				h[0] = Match{ID: id, Score: score}
+				heap.Fix(&h, 0)
+			}
+
+			return true
+		})
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		ret = make([]Match, len(h))
+		for i := len(ret) - 1; i >= 0; i-- {
+			ret[i] = heap.Pop(&h).(Match)
+		}
+
+		return ctx.Err()
+	})
+
+	return ret, err
+}
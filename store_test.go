@@ -0,0 +1,34 @@
+package sparse
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore()
+	s.Upsert(ctx, "a", NewVectorFromArray([]float64{1, 0, 0}))
+	s.Upsert(ctx, "b", NewVectorFromArray([]float64{1, 1, 0}))
+	s.Upsert(ctx, "zero", NewVector(3))
+
+	got, err := Query(ctx, s, NewVectorFromArray([]float64{1, 0, 0}), 10)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(got) != 2 || got[0].ID != "a" || got[1].ID != "b" {
+		t.Fatalf("Query() = %v, want a then b", got)
+	}
+
+	if got, err := Query(ctx, s, NewVectorFromArray([]float64{1, 0, 0}), 1<<40); err != nil || len(got) != 2 {
+		t.Fatalf("Query(k=1<<40) = %v, %v", got, err)
+	}
+
+	if got, err := Query(ctx, s, NewVectorFromArray([]float64{1, 0, 0}), 0); got != nil || err != nil {
+		t.Fatalf("Query(k=0) = %v, %v", got, err)
+	}
+
+	if _, err := Query(ctx, s, NewVectorFromArray([]float64{1, 0, 0}), -1); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("Query(k=-1) error = %v, want ErrOutOfRange", err)
+	}
+}
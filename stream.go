@@ -0,0 +1,105 @@
+package sparse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"math"
+)
+
+// An Encoder writes a stream of Vectors to an io.Writer. Each Vector is
+// framed as the uvarint length of its MarshalBinary encoding followed by
+// the encoding itself, whose indices are delta and varint encoded.
+type Encoder struct {
+	w     io.Writer
+	frame []byte
+	buf   []byte
+}
+
+// NewEncoder returns an Encoder writing to w. Each call to Encode makes
+// a single Write, so wrap w in a bufio.Writer when writing many small
+// Vectors.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode writes v to the stream. It returns ErrOutOfRange if v stores
+// entries outside its dimensions, as MarshalBinary does.
+func (e *Encoder) Encode(v Vector) error {
+	frame, err := v.AppendBinary(e.frame[:0])
+	if err != nil {
+		return err
+	}
+
+	e.frame = frame
+	e.buf = append(binary.AppendUvarint(e.buf[:0], uint64(len(frame))), frame...)
+	_, err = e.w.Write(e.buf)
+	return err
+}
+
+// A Decoder reads a stream of Vectors written by an Encoder from an
+// io.Reader, holding only one Vector's encoding in memory at a time.
+type Decoder struct {
+	r   byteReader
+	buf bytes.Buffer
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// NewDecoder returns a Decoder reading from r. It buffers r unless r is
+// already an io.ByteReader, so it may read beyond the last Vector it
+// returns.
+func NewDecoder(r io.Reader) *Decoder {
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return &Decoder{r: br}
+}
+
+// Decode reads the next Vector from the stream into v. It returns io.EOF
+// once the stream ends cleanly between Vectors, and io.ErrUnexpectedEOF
+// if it ends partway through one.
+func (d *Decoder) Decode(v *Vector) error {
+	size, err := binary.ReadUvarint(d.r)
+	if err == io.EOF {
+		return io.EOF
+	} else if errors.Is(err, io.ErrUnexpectedEOF) {
+		return io.ErrUnexpectedEOF
+	} else if err != nil || size > math.MaxInt64 {
+		return errMalformedBinary
+	}
+
+	// Copying rather than reading into a buffer of the given size keeps
+	// a corrupt length from allocating more than the stream holds.
+	d.buf.Reset()
+	if n, err := io.CopyN(&d.buf, d.r, int64(size)); err == io.EOF || (err == nil && n < int64(size)) {
+		return io.ErrUnexpectedEOF
+	} else if err != nil {
+		return err
+	}
+
+	return v.UnmarshalBinary(d.buf.Bytes())
+}
+
+// All returns an iterator over the remaining Vectors in the stream. It
+// stops at the end of the stream, or after yielding the first error
+// other than io.EOF.
+func (d *Decoder) All() iter.Seq2[Vector, error] {
+	return func(yield func(Vector, error) bool) {
+		for {
+			var v Vector
+			err := d.Decode(&v)
+			if err == io.EOF || !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package sparse
+
+import (
+	"maps"
+	"slices"
+)
+
+// Support returns the indices of the Vector's non-zero entries, in
+// ascending order.
+func (v Vector) Support() []int {
+	ret := make([]int, 0, len(v.data))
+	for n, d := range v.data {
+		if d != 0 {
+			ret = append(ret, n)
+		}
+	}
+
+	slices.Sort(ret)
+	return ret
+}
+
+// SupportUnion returns the indices that are non-zero in any of vs, in
+// ascending order.
+func SupportUnion(vs ...Vector) []int {
+	set := map[int]struct{}{}
+	for _, v := range vs {
+		for n, d := range v.data {
+			if d != 0 {
+				set[n] = struct{}{}
+			}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(set))
+}
+
+// SupportIntersection returns the indices that are non-zero in every one
+// of vs, in ascending order.
+func SupportIntersection(vs ...Vector) []int {
+	if len(vs) == 0 {
+		return []int{}
+	}
+
+	smallest := vs[0]
+	for _, v := range vs[1:] {
+		if len(v.data) < len(smallest.data) {
+			smallest = v
+		}
+	}
+
+	ret := []int{}
+	for _, n := range smallest.Support() {
+		if !slices.ContainsFunc(vs, func(v Vector) bool { return v.data[n] == 0 }) {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
+
+// SupportDifference returns the indices that are non-zero in v but in
+// none of others, in ascending order.
+func SupportDifference(v Vector, others ...Vector) []int {
+	ret := []int{}
+	for _, n := range v.Support() {
+		if !slices.ContainsFunc(others, func(o Vector) bool { return o.data[n] != 0 }) {
+			ret = append(ret, n)
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,103 @@
+package sparse
+
+import "sync"
+
+// syncShards is the number of independently locked shards of a
+// SyncVector.
+const syncShards = 32
+
+// SyncVector is a sparse vector that is safe for concurrent use, so
+// many goroutines can accumulate into it. Its entries are spread over
+// independently locked shards, so writes to different indices rarely
+// contend.
+type SyncVector struct {
+	dim    int
+	shards [syncShards]struct {
+		mu   sync.RWMutex
+		data map[int]float64
+	}
+}
+
+// NewSyncVector constructs a blank SyncVector with dim number of
+// dimensions.
+func NewSyncVector(dim int) *SyncVector {
+	s := &SyncVector{dim: dim}
+	for i := range s.shards {
+		s.shards[i].data = map[int]float64{}
+	}
+
+	return s
+}
+
+// shard returns the shard holding the n'th dimension.
+func (s *SyncVector) shard(n int) int {
+	return int(uint(n) % syncShards)
+}
+
+// Size is the dimensionality of the vector.
+func (s *SyncVector) Size() int {
+	return s.dim
+}
+
+// Set data on the n'th dimension.
+func (s *SyncVector) Set(n int, data float64) {
+	sh := &s.shards[s.shard(n)]
+	sh.mu.Lock()
+	sh.data[n] = data
+	sh.mu.Unlock()
+}
+
+// Add data to the n'th dimension atomically.
+func (s *SyncVector) Add(n int, data float64) {
+	sh := &s.shards[s.shard(n)]
+	sh.mu.Lock()
+	sh.data[n] += data
+	sh.mu.Unlock()
+}
+
+// AddVector adds each entry of v. Each entry is added atomically, but
+// concurrent readers may observe some of v's entries before others.
+func (s *SyncVector) AddVector(v Vector) {
+	for n, d := range v.data {
+		s.Add(n, d)
+	}
+}
+
+// Get data from the n'th dimension.
+func (s *SyncVector) Get(n int) float64 {
+	sh := &s.shards[s.shard(n)]
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+	return sh.data[n]
+}
+
+// Remove the entry on the n'th dimension.
+func (s *SyncVector) Remove(n int) {
+	sh := &s.shards[s.shard(n)]
+	sh.mu.Lock()
+	delete(sh.data, n)
+	sh.mu.Unlock()
+}
+
+// Vector returns a consistent snapshot of the SyncVector as a Vector,
+// holding every shard's lock while it copies.
+func (s *SyncVector) Vector() Vector {
+	for i := range s.shards {
+		s.shards[i].mu.RLock()
+		defer s.shards[i].mu.RUnlock()
+	}
+
+	size := 0
+	for i := range s.shards {
+		size += len(s.shards[i].data)
+	}
+
+	ret := NewVector(s.dim, WithCapacity(size))
+	for i := range s.shards {
+		for n, d := range s.shards[i].data {
+			ret.data[n] = d
+		}
+	}
+
+	return ret
+}
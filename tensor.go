@@ -0,0 +1,297 @@
+package sparse
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Tensor is a sparse tensor of arbitrary rank, stored in coordinate (COO)
+// format: a list of coordinates with their non-zero values. Tensors are
+// used by pointer, so copies share their entries; see Clone.
+type Tensor struct {
+	shape  []int
+	coords [][]int
+	values []float64
+	index  map[int]int // linearized coordinate to position in coords
+}
+
+// NewTensor constructs a blank Tensor with the given shape, one
+// dimensionality per mode. It returns ErrOutOfRange if a dimensionality
+// is negative or the Tensor has too many elements to index.
+func NewTensor(shape ...int) (*Tensor, error) {
+	size := 1
+	for _, n := range shape {
+		if n < 0 {
+			return nil, fmt.Errorf("%w: negative dim %d", ErrOutOfRange, n)
+		} else if n > 0 && size > math.MaxInt/n {
+			return nil, fmt.Errorf("%w: shape %v overflows", ErrOutOfRange, shape)
+		}
+
+		size *= n
+	}
+
+	return &Tensor{shape: slices.Clone(shape), index: map[int]int{}}, nil
+}
+
+// Clone returns a deep copy of the Tensor.
+func (t *Tensor) Clone() *Tensor {
+	ret := &Tensor{
+		shape:  slices.Clone(t.shape),
+		coords: make([][]int, len(t.coords)),
+		values: slices.Clone(t.values),
+		index:  maps.Clone(t.index),
+	}
+
+	for i, coord := range t.coords {
+		ret.coords[i] = slices.Clone(coord)
+	}
+
+	return ret
+}
+
+// Rank is the number of modes of the Tensor.
+func (t *Tensor) Rank() int {
+	return len(t.shape)
+}
+
+// Shape returns the dimensionality of each mode of the Tensor.
+func (t *Tensor) Shape() []int {
+	return slices.Clone(t.shape)
+}
+
+// NNZ is the number of non-zero entries stored in the Tensor.
+func (t *Tensor) NNZ() int {
+	return len(t.values)
+}
+
+// linear maps coord to its position in a row-major layout, returning
+// ErrOutOfRange if it falls outside the Tensor's shape.
+func (t *Tensor) linear(coord []int) (int, error) {
+	if len(coord) != len(t.shape) {
+		return 0, fmt.Errorf("%w: %d coordinates for rank %d", ErrDimensionMismatch, len(coord), len(t.shape))
+	}
+
+	ret := 0
+	for k, n := range coord {
+		if n < 0 || n >= t.shape[k] {
+			return 0, fmt.Errorf("%w: %d not in [0, %d) on mode %d", ErrOutOfRange, n, t.shape[k], k)
+		}
+
+		ret = ret*t.shape[k] + n
+	}
+
+	return ret, nil
+}
+
+// Set data at coord, returning ErrOutOfRange if it falls outside the
+// Tensor's shape. Setting zero removes the entry.
+func (t *Tensor) Set(coord []int, data float64) error {
+	key, err := t.linear(coord)
+	if err != nil {
+		return err
+	}
+
+	i, ok := t.index[key]
+	switch {
+	case ok && data == 0:
+		t.remove(key, i)
+		return nil
+	case ok:
+		t.values[i] = data
+		return nil
+	case data == 0:
+		return nil
+	}
+
+	if t.index == nil {
+		t.index = map[int]int{}
+	}
+
+	t.index[key] = len(t.values)
+	t.coords = append(t.coords, slices.Clone(coord))
+	t.values = append(t.values, data)
+	return nil
+}
+
+// remove deletes the entry at position i, whose linearized coordinate
+// is key, keeping the rest in insertion order.
+func (t *Tensor) remove(key int, i int) {
+	delete(t.index, key)
+	t.coords = slices.Delete(t.coords, i, i+1)
+	t.values = slices.Delete(t.values, i, i+1)
+	for j := i; j < len(t.coords); j++ {
+		k, _ := t.linear(t.coords[j])
+		t.index[k] = j
+	}
+}
+
+// add accumulates data at coord, which must be in range.
+func (t *Tensor) add(coord []int, data float64) {
+	key, _ := t.linear(coord)
+	if i, ok := t.index[key]; ok {
+		t.values[i] += data
+		return
+	}
+
+	t.index[key] = len(t.values)
+	t.coords = append(t.coords, slices.Clone(coord))
+	t.values = append(t.values, data)
+}
+
+// compact drops the entries that add cancelled to zero.
+func (t *Tensor) compact() {
+	k := 0
+	for i, d := range t.values {
+		if d != 0 {
+			t.coords[k], t.values[k] = t.coords[i], d
+			k++
+		}
+	}
+
+	if k == len(t.values) {
+		return
+	}
+
+	t.coords, t.values = t.coords[:k], t.values[:k]
+	clear(t.index)
+	for i, coord := range t.coords {
+		key, _ := t.linear(coord)
+		t.index[key] = i
+	}
+}
+
+// Get data at coord. Coordinates outside the Tensor's shape read as zero.
+func (t *Tensor) Get(coord []int) float64 {
+	key, err := t.linear(coord)
+	if err != nil {
+		return 0
+	}
+
+	if i, ok := t.index[key]; ok {
+		return t.values[i]
+	}
+
+	return 0
+}
+
+// NonZeros calls fn with each stored coordinate and its value, in
+// insertion order. fn must not retain or modify coord.
+func (t *Tensor) NonZeros(fn func(coord []int, data float64)) {
+	for i, coord := range t.coords {
+		fn(coord, t.values[i])
+	}
+}
+
+// checkMode returns ErrOutOfRange unless mode is one of the Tensor's.
+func (t *Tensor) checkMode(mode int) error {
+	if mode < 0 || mode >= len(t.shape) {
+		return fmt.Errorf("%w: mode %d not in [0, %d)", ErrOutOfRange, mode, len(t.shape))
+	}
+
+	return nil
+}
+
+// Unfold matricizes the Tensor along mode, returning one row per index of
+// that mode. Columns enumerate the remaining modes with the earliest
+// varying fastest, following Kolda and Bader.
+func (t *Tensor) Unfold(mode int) ([]Vector, error) {
+	if err := t.checkMode(mode); err != nil {
+		return nil, err
+	}
+
+	cols := 1
+	for k, n := range t.shape {
+		if k != mode {
+			cols *= n
+		}
+	}
+
+	rows := make([]Vector, t.shape[mode])
+	for i := range rows {
+		rows[i] = NewVector(cols)
+	}
+
+	for i, coord := range t.coords {
+		col, stride := 0, 1
+		for k, n := range coord {
+			if k != mode {
+				col += n * stride
+				stride *= t.shape[k]
+			}
+		}
+
+		rows[coord[mode]].data[col] = t.values[i]
+	}
+
+	return rows, nil
+}
+
+// TTV multiplies the Tensor by v along mode, returning a Tensor with that
+// mode contracted away. It returns ErrDimensionMismatch unless v's
+// dimensionality matches the mode's.
+func (t *Tensor) TTV(v Vector, mode int) (*Tensor, error) {
+	if err := t.checkMode(mode); err != nil {
+		return nil, err
+	} else if v.dim != t.shape[mode] {
+		return nil, fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v.dim, t.shape[mode])
+	}
+
+	ret, _ := NewTensor(slices.Delete(t.Shape(), mode, mode+1)...)
+	coord := make([]int, 0, len(t.shape)-1)
+	for i, c := range t.coords {
+		if d, ok := v.data[c[mode]]; ok {
+			coord = append(append(coord[:0], c[:mode]...), c[mode+1:]...)
+			ret.add(coord, t.values[i]*d)
+		}
+	}
+
+	ret.compact()
+	return ret, nil
+}
+
+// TTM multiplies the Tensor along mode by the matrix whose rows are
+// given, replacing that mode's dimensionality with the number of rows.
+// It returns ErrDimensionMismatch unless every row's dimensionality
+// matches the mode's.
+func (t *Tensor) TTM(rows []Vector, mode int) (*Tensor, error) {
+	if err := t.checkMode(mode); err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		row  int
+		data float64
+	}
+
+	cols := make(map[int][]entry)
+	for j, row := range rows {
+		if row.dim != t.shape[mode] {
+			return nil, fmt.Errorf("%w: row %d: %d != %d", ErrDimensionMismatch, j, row.dim, t.shape[mode])
+		}
+
+		for n, d := range row.data {
+			cols[n] = append(cols[n], entry{j, d})
+		}
+	}
+
+	shape := t.Shape()
+	shape[mode] = len(rows)
+	ret, err := NewTensor(shape...)
+	if err != nil {
+		return nil, err
+	}
+
+	coord := make([]int, len(shape))
+	for i, c := range t.coords {
+		copy(coord, c)
+		for _, e := range cols[c[mode]] {
+			coord[mode] = e.row
+			ret.add(coord, t.values[i]*e.data)
+		}
+	}
+
+	ret.compact()
+	return ret, nil
+}
@@ -0,0 +1,53 @@
+package sparse
+
+import "testing"
+
+func TestTensorCopiesShareEntries(t *testing.T) {
+	a, err := NewTensor(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a.Set([]int{0, 1}, 4)
+	b := a
+	b.Set([]int{1, 2}, 5)
+	if a.Get([]int{0, 1}) != 4 || a.Get([]int{1, 2}) != 5 || a.NNZ() != 2 {
+		t.Fatalf("a after b.Set: NNZ %d", a.NNZ())
+	}
+
+	c := a.Clone()
+	c.Set([]int{0, 0}, 1)
+	if a.NNZ() != 2 || c.NNZ() != 3 {
+		t.Fatalf("Clone shares entries: %d, %d", a.NNZ(), c.NNZ())
+	}
+}
+
+func TestTensorSetZero(t *testing.T) {
+	x, _ := NewTensor(2, 2, 2)
+	x.Set([]int{0, 0, 0}, 1)
+	x.Set([]int{1, 1, 1}, 2)
+	x.Set([]int{0, 1, 0}, 3)
+	x.Set([]int{1, 0, 1}, 0)
+	x.Set([]int{0, 0, 0}, 0)
+	if x.NNZ() != 2 || x.Get([]int{0, 0, 0}) != 0 || x.Get([]int{1, 1, 1}) != 2 || x.Get([]int{0, 1, 0}) != 3 {
+		t.Fatalf("NNZ %d after clearing", x.NNZ())
+	}
+
+	var order []float64
+	x.NonZeros(func(coord []int, d float64) { order = append(order, d) })
+	if len(order) != 2 || order[0] != 2 || order[1] != 3 {
+		t.Fatalf("NonZeros order %v, want [2 3]", order)
+	}
+}
+
+func TestTensorTTVCancels(t *testing.T) {
+	x, _ := NewTensor(1, 2)
+	x.Set([]int{0, 0}, 1)
+	x.Set([]int{0, 1}, 1)
+	y, err := x.TTV(NewVectorFromArray([]float64{1, -1}), 1)
+	if err != nil {
+		t.Fatal(err)
+	} else if y.NNZ() != 0 {
+		t.Fatalf("TTV stored %d cancelled entries", y.NNZ())
+	}
+}
@@ -0,0 +1,97 @@
+package sparse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errMalformedText = errors.New("sparse: malformed text encoding")
+
+// MarshalText implements encoding.TextMarshaler, encoding the Vector as
+// its dim followed by each stored entry in ascending index order, e.g.
+// "dim=1000; 3:1.5 17:-0.25 998:2". Values are formatted exactly, so
+// the text decodes to an equal Vector. It returns ErrOutOfRange if the
+// Vector stores entries outside its dimensions.
+func (v Vector) MarshalText() ([]byte, error) {
+	if err := checkInRange(v); err != nil {
+		return nil, err
+	}
+
+	buf := append([]byte("dim="), strconv.Itoa(v.dim)...)
+	buf = append(buf, ';')
+	for n, d := range v.SortedNonZeros() {
+		buf = append(buf, ' ')
+		buf = strconv.AppendInt(buf, int64(n), 10)
+		buf = append(buf, ':')
+		buf = strconv.AppendFloat(buf, d, 'g', -1, 64)
+	}
+
+	return buf, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding the syntax
+// of MarshalText and replacing the contents of v but keeping the options
+// it was constructed with. Entries may be in any order and separated by
+// any whitespace. It returns ErrOutOfRange if an entry falls outside the
+// given dim, unless v's Bounds are AutoGrow, which grows it to fit, and
+// ErrDuplicateIndex if an index repeats.
+func (v *Vector) UnmarshalText(text []byte) error {
+	ret, err := parse(string(text), v.blank(0, 0))
+	if err != nil {
+		return err
+	}
+
+	*v = ret
+	return nil
+}
+
+// Parse a Vector from the syntax of MarshalText, for Vectors in config
+// files, CSV columns and command-line flags.
+func Parse(s string) (Vector, error) {
+	return parse(s, NewVector(0))
+}
+
+// parse implements Parse and UnmarshalText, decoding s into the empty
+// Vector ret.
+func parse(s string, ret Vector) (Vector, error) {
+	header, body, ok := strings.Cut(strings.TrimSpace(s), ";")
+	size, found := strings.CutPrefix(strings.TrimSpace(header), "dim=")
+	if !ok || !found {
+		return Vector{}, fmt.Errorf("%w: want \"dim=N;\" prefix in %q", errMalformedText, s)
+	}
+
+	dim, err := strconv.Atoi(strings.TrimSpace(size))
+	if err != nil {
+		return Vector{}, fmt.Errorf("%w: dim %q", errMalformedText, size)
+	} else if dim < 0 {
+		return Vector{}, fmt.Errorf("%w: negative dim %d", ErrOutOfRange, dim)
+	}
+
+	ret.dim = dim
+	for _, field := range strings.Fields(body) {
+		index, value, ok := strings.Cut(field, ":")
+		if !ok {
+			return Vector{}, fmt.Errorf("%w: entry %q", errMalformedText, field)
+		}
+
+		n, err := strconv.Atoi(index)
+		if err != nil {
+			return Vector{}, fmt.Errorf("%w: index %q", errMalformedText, index)
+		}
+
+		d, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return Vector{}, fmt.Errorf("%w: value %q", errMalformedText, value)
+		} else if _, dup := ret.data[n]; dup {
+			return Vector{}, fmt.Errorf("%w: %d", ErrDuplicateIndex, n)
+		} else if err := ret.admit(n); err != nil {
+			return Vector{}, err
+		}
+
+		ret.data[n] = d
+	}
+
+	return ret, nil
+}
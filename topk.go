@@ -0,0 +1,64 @@
+package sparse
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Entry is an index of a Vector and its value.
+type Entry struct {
+	Index int
+	Value float64
+}
+
+// entryHeap is a min-heap of Entries by magnitude, with higher indices
+// lower on ties, used to keep the k largest seen so far.
+type entryHeap []Entry
+
+func (h entryHeap) Len() int           { return len(h) }
+func (h entryHeap) Less(i, j int) bool { return ranksBelow(h[i], h[j]) }
+func (h entryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *entryHeap) Push(x any)        { *h = append(*h, x.(Entry)) }
+func (h *entryHeap) Pop() any {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
+
+// ranksBelow reports whether a ranks below b in TopK.
+func ranksBelow(a Entry, b Entry) bool {
+	x, y := math.Abs(a.Value), math.Abs(b.Value)
+	return x < y || (x == y && a.Index > b.Index)
+}
+
+// TopK returns the k non-zero entries of largest magnitude, largest
+// first, preferring lower indices on ties. It returns fewer if the
+// Vector has fewer non-zero entries. NaNs are skipped.
+func (v Vector) TopK(k int) []Entry {
+	if k <= 0 {
+		return nil
+	}
+
+	h := make(entryHeap, 0, min(k, len(v.data)))
+	for n, d := range v.data {
+		if d == 0 || d != d {
+			continue
+		}
+
+		e := Entry{Index: n, Value: d}
+		if len(h) < k {
+			heap.Push(&h, e)
+		} else if ranksBelow(h[0], e) {
+			h[0] = e
+			heap.Fix(&h, 0)
+		}
+	}
+
+	ret := make([]Entry, len(h))
+	for i := len(ret) - 1; i >= 0; i-- {
+		ret[i] = heap.Pop(&h).(Entry)
+	}
+
+	return ret
+}
@@ -0,0 +1,69 @@
+package sparse
+
+import (
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"sync/atomic"
+)
+
+// Tracer starts a span around one of the package's heavy operations:
+// Query, the Context variants of Matrix multiplication, KMeans and the
+// batch similarities, and the AddAll builds of the indexes. The
+// returned end func is called with the operation's error once it
+// completes. Tracer lets callers plug in a tracing system (see the
+// sparseotel package) without the core package depending on it.
+type Tracer interface {
+	Start(ctx context.Context, op string) (context.Context, func(err error))
+}
+
+var tracer atomic.Pointer[Tracer]
+
+// SetTracer installs t to trace heavy operations. A nil t disables
+// tracing.
+func SetTracer(t Tracer) {
+	if t == nil {
+		tracer.Store(nil)
+		return
+	}
+
+	tracer.Store(&t)
+}
+
+// instrument runs fn as the operation op, inside a span of the
+// installed Tracer and with a "sparse_op" pprof label, so CPU profiles
+// attribute its cost to op and to any labels already on ctx (e.g. a
+// tenant).
+func instrument(ctx context.Context, op string, fn func(ctx context.Context) error) (err error) {
+	if t := tracer.Load(); t != nil {
+		var end func(error)
+		ctx, end = (*t).Start(ctx, op)
+		defer func() { end(err) }()
+	}
+
+	pprof.Do(ctx, pprof.Labels("sparse_op", op), func(ctx context.Context) {
+		err = fn(ctx)
+	})
+
+	return err
+}
+
+// addAll implements the AddAll methods of the indexes, adding vs under
+// ids with add as the traced operation op.
+func addAll(ctx context.Context, op string, ids []string, vs []Vector, add func(id string, v Vector)) error {
+	if len(ids) != len(vs) {
+		return fmt.Errorf("%w: %d ids but %d vectors", ErrDimensionMismatch, len(ids), len(vs))
+	}
+
+	return instrument(ctx, op, func(ctx context.Context) error {
+		for i, v := range vs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			add(ids[i], v)
+		}
+
+		return nil
+	})
+}
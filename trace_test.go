@@ -0,0 +1,60 @@
+package sparse
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+)
+
+type recorder struct {
+	mu  sync.Mutex
+	ops []string
+}
+
+func (r *recorder) Start(ctx context.Context, op string) (context.Context, func(error)) {
+	r.mu.Lock()
+	r.ops = append(r.ops, op)
+	r.mu.Unlock()
+	return ctx, func(error) {}
+}
+
+func TestInstrumentedOperations(t *testing.T) {
+	r := &recorder{}
+	SetTracer(r)
+	defer SetTracer(nil)
+
+	ctx := context.Background()
+	m := NewMatrixFromRows([]Vector{NewVectorFromArray([]float64{1, 2})})
+	if _, err := m.MulVecContext(ctx, NewVectorFromArray([]float64{1, 1})); err != nil {
+		t.Fatal(err)
+	} else if _, err := m.MulContext(ctx, m.T()); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, vs := []string{"a"}, []Vector{NewVectorFromArray([]float64{1, 0})}
+	NewLSHIndex(2, 4, 1).AddAll(ctx, ids, vs)
+	NewMinHashIndex(2, 2, 1).AddAll(ctx, ids, vs)
+	NewInvertedIndex(DotProduct).AddAll(ctx, ids, vs)
+
+	want := []string{"Matrix.MulVec", "Matrix.Mul", "LSHIndex.AddAll", "MinHashIndex.AddAll", "InvertedIndex.AddAll"}
+	if !slices.Equal(r.ops, want) {
+		t.Fatalf("traced %v, want %v", r.ops, want)
+	}
+}
+
+func TestAddAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	idx := NewInvertedIndex(BM25)
+	err := idx.AddAll(ctx, []string{"a", "b"}, []Vector{NewVector(2), NewVector(2)})
+	if !errors.Is(err, context.Canceled) || idx.Len() != 0 {
+		t.Fatalf("AddAll() = %v with %d added, want context.Canceled", err, idx.Len())
+	}
+
+	if err := idx.AddAll(context.Background(), []string{"a"}, nil); !errors.Is(err, ErrDimensionMismatch) {
+		t.Fatalf("AddAll() error = %v, want ErrDimensionMismatch", err)
+	}
+}
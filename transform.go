@@ -0,0 +1,73 @@
+package sparse
+
+import "math"
+
+// Abs returns a Vector of the absolute values of this one's entries.
+func (v Vector) Abs() Vector {
+	return v.Map(func(_ int, d float64) float64 { return math.Abs(d) })
+}
+
+// Sqrt returns a Vector of the square roots of this one's entries,
+// with NaN for negative entries.
+func (v Vector) Sqrt() Vector {
+	return v.Map(func(_ int, d float64) float64 { return math.Sqrt(d) })
+}
+
+// Log1p returns a Vector of the natural logarithm of one plus each of
+// this one's entries, as used to dampen counts. Entries below -1 become
+// NaN.
+func (v Vector) Log1p() Vector {
+	return v.Map(func(_ int, d float64) float64 { return math.Log1p(d) })
+}
+
+// Clip returns a Vector with each value limited to [lo, hi]. If zero is
+// outside that range, the implicit zeros clip to lo or hi too, and the
+// result stores every dimension.
+func (v Vector) Clip(lo float64, hi float64) Vector {
+	ret := v.Map(func(_ int, d float64) float64 { return max(lo, min(hi, d)) })
+	if fill := max(lo, min(hi, 0)); fill != 0 {
+		for n := range v.dim {
+			if ret.data[n] == 0 {
+				ret.data[n] = fill
+			}
+		}
+	}
+
+	return ret
+}
+
+// Softmax returns the softmax of the Vector's values over all its
+// dimensions, a probability distribution summing to one. Implicit zeros
+// take part as values of zero and receive non-zero probability, so the
+// result stores every dimension. Entries stored outside the Vector's
+// dimensions are ignored.
+func (v Vector) Softmax() Vector {
+	// Shifting by the largest value, implicit zeros included, keeps
+	// math.Exp from overflowing.
+	shift := math.Inf(-1)
+	stored := 0
+	for n, d := range v.data {
+		if n >= 0 && n < v.dim {
+			shift = max(shift, d)
+			stored++
+		}
+	}
+
+	if stored < v.dim {
+		shift = max(shift, 0)
+	}
+
+	ret := NewVector(v.dim, WithCapacity(v.dim))
+	sum := float64(0)
+	for n := range v.dim {
+		e := math.Exp(v.data[n] - shift)
+		ret.data[n] = e
+		sum += e
+	}
+
+	for n, e := range ret.data {
+		ret.data[n] = e / sum
+	}
+
+	return ret
+}
@@ -0,0 +1,26 @@
+// Package sparse is the next major version of
+// github.com/angadn/sparse, in its own module at
+// github.com/angadn/sparse/v2. It is a work in progress and its API may
+// still change until it is tagged.
+//
+// It settles the questions the first version left open:
+//
+//   - Vectors are immutable values. Operations never modify their
+//     operands, so Vectors can be copied and shared freely, including
+//     between goroutines. Updates return a new Vector.
+//   - Invalid operations return errors: out-of-range indices,
+//     mismatched dimensions and malformed input are reported rather than
+//     silently tolerated.
+//   - Entries are enumerated with iterators (see Vector.All) in
+//     ascending index order, so iteration and output are deterministic.
+//   - Construction is configured with functional options.
+//
+// Storage is a sorted slice of indices with a parallel slice of values,
+// which makes iteration ordered and lets binary operations merge their
+// operands in a single pass.
+//
+// The first version stays supported. FromV1 and Vector.V1 convert
+// between the two so that callers can migrate one package at a time;
+// once this module is tagged, the first version is meant to become a
+// thin compatibility layer over it as its features move here.
+package sparse
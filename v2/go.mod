@@ -0,0 +1,5 @@
+module github.com/angadn/sparse/v2
+
+go 1.24
+
+require github.com/angadn/sparse v0.0.0-20261014063641-3b004cc4c4b8
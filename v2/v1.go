@@ -0,0 +1,30 @@
+package sparse
+
+import (
+	v1 "github.com/angadn/sparse"
+)
+
+// FromV1 converts a Vector of the first version of the package. It
+// returns ErrOutOfRange if v stores entries outside its dimensions,
+// which the first version tolerates.
+func FromV1(v v1.Vector) (Vector, error) {
+	indices := make([]int, 0, v.NNZ())
+	values := make([]float64, 0, v.NNZ())
+	for n, d := range v.SortedNonZeros() {
+		indices = append(indices, n)
+		values = append(values, d)
+	}
+
+	return New(v.Size(), indices, values)
+}
+
+// V1 converts the Vector to the first version of the package, for
+// callers that have not yet migrated.
+func (v Vector) V1() v1.Vector {
+	ret, err := v1.NewVectorFromPairs(v.dim, v.indices, v.values)
+	if err != nil {
+		panic(err) // unreachable: v's indices are in range and unique
+	}
+
+	return ret
+}
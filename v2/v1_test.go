@@ -0,0 +1,35 @@
+package sparse
+
+import (
+	"errors"
+	"testing"
+
+	v1 "github.com/angadn/sparse"
+)
+
+func TestV1RoundTrip(t *testing.T) {
+	v, err := New(10, []int{7, 2, 4}, []float64{1.5, -2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := v.V1()
+	if old.Size() != 10 || old.NNZ() != 3 || old.Get(2) != -2 {
+		t.Fatalf("V1() = %v", old)
+	}
+
+	got, err := FromV1(old)
+	if err != nil {
+		t.Fatal(err)
+	} else if !Equal(got, v) {
+		t.Fatalf("FromV1(V1()) = %v, want %v", got, v)
+	}
+}
+
+func TestFromV1OutOfRange(t *testing.T) {
+	old := v1.NewVector(3)
+	old.Set(5, 1)
+	if _, err := FromV1(old); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("FromV1() error = %v, want ErrOutOfRange", err)
+	}
+}
@@ -0,0 +1,294 @@
+package sparse
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrOutOfRange is returned when an index falls outside a Vector's
+	// dimensions.
+	ErrOutOfRange = errors.New("sparse: index out of range")
+
+	// ErrDimensionMismatch is returned when operands have incompatible
+	// dimensions.
+	ErrDimensionMismatch = errors.New("sparse: dimension mismatch")
+
+	// ErrDuplicateIndex is returned when an index is given more than
+	// once.
+	ErrDuplicateIndex = errors.New("sparse: duplicate index")
+)
+
+// Vector is an immutable sparse vector. The zero value is an empty,
+// zero-dimensional Vector.
+type Vector struct {
+	dim     int
+	indices []int
+	values  []float64
+}
+
+// Option configures the construction of a Vector.
+type Option func(*options)
+
+type options struct {
+	sumDuplicates bool
+}
+
+// SumDuplicates sums the values of repeated indices rather than
+// rejecting them with ErrDuplicateIndex.
+func SumDuplicates() Option {
+	return func(o *options) {
+		o.sumDuplicates = true
+	}
+}
+
+// New constructs a Vector of dim dimensions from parallel slices of
+// indices and values, which need not be sorted. Zero values are not
+// stored.
+func New(dim int, indices []int, values []float64, opts ...Option) (Vector, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if dim < 0 {
+		return Vector{}, fmt.Errorf("%w: negative dim %d", ErrOutOfRange, dim)
+	} else if len(indices) != len(values) {
+		return Vector{}, fmt.Errorf("%w: %d indices but %d values", ErrDimensionMismatch, len(indices), len(values))
+	}
+
+	order := make([]int, len(indices))
+	for i, n := range indices {
+		if n < 0 || n >= dim {
+			return Vector{}, fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, dim)
+		}
+
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return indices[a] - indices[b]
+	})
+
+	ret := Vector{dim: dim}
+	for _, i := range order {
+		if last := len(ret.indices) - 1; last >= 0 && ret.indices[last] == indices[i] {
+			if !o.sumDuplicates {
+				return Vector{}, fmt.Errorf("%w: %d", ErrDuplicateIndex, indices[i])
+			}
+
+			ret.values[last] += values[i]
+			continue
+		}
+
+		ret.indices = append(ret.indices, indices[i])
+		ret.values = append(ret.values, values[i])
+	}
+
+	return ret.compact(), nil
+}
+
+// FromMap constructs a Vector of dim dimensions from a map of entries.
+func FromMap(dim int, entries map[int]float64) (Vector, error) {
+	indices := make([]int, 0, len(entries))
+	values := make([]float64, 0, len(entries))
+	for n, d := range entries {
+		indices = append(indices, n)
+		values = append(values, d)
+	}
+
+	return New(dim, indices, values)
+}
+
+// FromDense constructs a Vector from the non-zero values of a slice.
+func FromDense(arr []float64) Vector {
+	ret := Vector{dim: len(arr)}
+	for n, d := range arr {
+		if d != 0 {
+			ret.indices = append(ret.indices, n)
+			ret.values = append(ret.values, d)
+		}
+	}
+
+	return ret
+}
+
+// compact drops zero values, which arithmetic can produce.
+func (v Vector) compact() Vector {
+	k := 0
+	for i, d := range v.values {
+		if d != 0 {
+			v.indices[k], v.values[k] = v.indices[i], d
+			k++
+		}
+	}
+
+	v.indices, v.values = v.indices[:k:k], v.values[:k:k]
+	return v
+}
+
+// Dim is the dimensionality of the Vector.
+func (v Vector) Dim() int {
+	return v.dim
+}
+
+// NNZ is the number of non-zero entries.
+func (v Vector) NNZ() int {
+	return len(v.indices)
+}
+
+// At returns the n'th value, or ErrOutOfRange.
+func (v Vector) At(n int) (float64, error) {
+	if n < 0 || n >= v.dim {
+		return 0, fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+	}
+
+	if i, ok := slices.BinarySearch(v.indices, n); ok {
+		return v.values[i], nil
+	}
+
+	return 0, nil
+}
+
+// With returns a copy of the Vector with its n'th value set to d.
+func (v Vector) With(n int, d float64) (Vector, error) {
+	if n < 0 || n >= v.dim {
+		return Vector{}, fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+	}
+
+	i, ok := slices.BinarySearch(v.indices, n)
+	ret := Vector{dim: v.dim, indices: slices.Clone(v.indices), values: slices.Clone(v.values)}
+	switch {
+	case ok && d == 0:
+		ret.indices = slices.Delete(ret.indices, i, i+1)
+		ret.values = slices.Delete(ret.values, i, i+1)
+	case ok:
+		ret.values[i] = d
+	case d != 0:
+		ret.indices = slices.Insert(ret.indices, i, n)
+		ret.values = slices.Insert(ret.values, i, d)
+	}
+
+	return ret, nil
+}
+
+// All iterates the non-zero entries in ascending index order.
+func (v Vector) All() iter.Seq2[int, float64] {
+	return func(yield func(int, float64) bool) {
+		for i, n := range v.indices {
+			if !yield(n, v.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Dense materializes the Vector as a slice of its values.
+func (v Vector) Dense() []float64 {
+	ret := make([]float64, v.dim)
+	for i, n := range v.indices {
+		ret[n] = v.values[i]
+	}
+
+	return ret
+}
+
+// Scale multiplies the Vector by a scalar.
+func (v Vector) Scale(alpha float64) Vector {
+	ret := Vector{dim: v.dim, indices: slices.Clone(v.indices), values: make([]float64, len(v.values))}
+	for i, d := range v.values {
+		ret.values[i] = alpha * d
+	}
+
+	return ret.compact()
+}
+
+// Norm is the Euclidean norm of the Vector.
+func (v Vector) Norm() float64 {
+	ret := float64(0)
+	for _, d := range v.values {
+		ret += d * d
+	}
+
+	return math.Sqrt(ret)
+}
+
+// Add two Vectors of the same dimensionality.
+func Add(v1, v2 Vector) (Vector, error) {
+	if v1.dim != v2.dim {
+		return Vector{}, fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v1.dim, v2.dim)
+	}
+
+	ret := Vector{dim: v1.dim}
+	merge(v1, v2, func(n int, d1, d2 float64) {
+		ret.indices = append(ret.indices, n)
+		ret.values = append(ret.values, d1+d2)
+	})
+
+	return ret.compact(), nil
+}
+
+// Dot product of two Vectors of the same dimensionality.
+func Dot(v1, v2 Vector) (float64, error) {
+	if v1.dim != v2.dim {
+		return 0, fmt.Errorf("%w: %d != %d", ErrDimensionMismatch, v1.dim, v2.dim)
+	}
+
+	ret := float64(0)
+	merge(v1, v2, func(n int, d1, d2 float64) {
+		ret += d1 * d2
+	})
+
+	return ret, nil
+}
+
+// merge calls fn for every index stored in either Vector, in ascending
+// order, with zero for a value the other does not store.
+func merge(v1, v2 Vector, fn func(n int, d1, d2 float64)) {
+	i, j := 0, 0
+	for i < len(v1.indices) || j < len(v2.indices) {
+		switch {
+		case j == len(v2.indices) || (i < len(v1.indices) && v1.indices[i] < v2.indices[j]):
+			fn(v1.indices[i], v1.values[i], 0)
+			i++
+		case i == len(v1.indices) || v2.indices[j] < v1.indices[i]:
+			fn(v2.indices[j], 0, v2.values[j])
+			j++
+		default:
+			fn(v1.indices[i], v1.values[i], v2.values[j])
+			i++
+			j++
+		}
+	}
+}
+
+// Equal reports whether two Vectors have the same dimensionality and
+// values.
+func Equal(v1, v2 Vector) bool {
+	return v1.dim == v2.dim && slices.Equal(v1.indices, v2.indices) && slices.Equal(v1.values, v2.values)
+}
+
+// String formats the Vector as e.g. "dim=10 {1:0.5, 7:-2}".
+func (v Vector) String() string {
+	var sb strings.Builder
+	sb.WriteString("dim=")
+	sb.WriteString(strconv.Itoa(v.dim))
+	sb.WriteString(" {")
+	for i, n := range v.indices {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(strconv.Itoa(n))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatFloat(v.values[i], 'g', -1, 64))
+	}
+
+	sb.WriteByte('}')
+	return sb.String()
+}
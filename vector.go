@@ -1,14 +1,33 @@
 package sparse
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
 )
 
+// ErrOutOfRange is returned when an index falls outside a Vector's
+// dimensions.
+var ErrOutOfRange = errors.New("sparse: index out of range")
+
+// ErrDuplicateIndex is returned when an index is given more than once.
+var ErrDuplicateIndex = errors.New("sparse: duplicate index")
+
+// ErrNaN is returned when a Vector rejects a NaN value.
+var ErrNaN = errors.New("sparse: NaN value")
+
 // Vector is an indexed representation of a multidimensional vector.
+// The zero value is an empty, zero-dimensional Vector ready to use.
 type Vector struct {
-	dim  int
-	data map[int]float64
+	dim   int
+	data  map[int]float64
+	opts  *options
+	norms *normCache
 }
 
 // Size is the dimensionality of the vector.
@@ -16,19 +35,100 @@ func (v Vector) Size() int {
 	return v.dim
 }
 
-// Grow Vector to n-dimensions, if n is greater than the current
-// number of dimensions.
-func (v Vector) Grow(n int) Vector {
+// Grow Vector to n-dimensions in place, if n is greater than the
+// current number of dimensions. It returns the grown Vector for
+// convenience.
+func (v *Vector) Grow(n int) Vector {
 	if n > v.dim {
 		v.dim = n
 	}
 
-	return v
+	return *v
+}
+
+// Set data on the n'th dimension. Indices outside the Vector's
+// dimensions are handled according to its Bounds, and NaNs according to
+// its NaNPolicy; Set panics on writes they reject.
+func (v *Vector) Set(n int, data float64) {
+	if err := v.set(n, data, v.opts.boundsMode() == Lenient); err != nil {
+		panic(err)
+	}
+}
+
+// SetChecked sets data on the n'th dimension, returning ErrOutOfRange if
+// n falls outside the Vector's dimensions. A Vector constructed with
+// WithBounds(AutoGrow) instead grows to fit a positive n. It returns
+// ErrNaN for NaNs rejected by the Vector's NaNPolicy.
+func (v *Vector) SetChecked(n int, data float64) error {
+	return v.set(n, data, false)
 }
 
-// Set data on the n'th dimension.
-func (v Vector) Set(n int, data float64) {
+// set implements Set and SetChecked. lenient stores out-of-range writes
+// that would otherwise be rejected.
+func (v *Vector) set(n int, data float64, lenient bool) error {
+	if n < 0 || n >= v.dim {
+		if n >= 0 && v.opts.boundsMode() == AutoGrow {
+			v.dim = n + 1
+		} else if !lenient {
+			return fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+		}
+	}
+
+	if math.IsNaN(data) && v.opts.nanPolicy() == NaNReject {
+		return fmt.Errorf("%w: at %d", ErrNaN, n)
+	} else if v.opts.dropped(data) {
+		v.Remove(n)
+		return nil
+	}
+
+	v.lazyInit()
+	v.invalidate()
+	old := v.data[n]
 	v.data[n] = data
+	if obs := v.opts.observerOf(); obs != nil {
+		obs.Changed(n, old, data)
+	}
+
+	return nil
+}
+
+// Remove the entry on the n'th dimension, restoring it to an implicit
+// zero.
+func (v *Vector) Remove(n int) {
+	old, ok := v.data[n]
+	delete(v.data, n)
+	v.invalidate()
+	if obs := v.opts.observerOf(); ok && obs != nil {
+		obs.Removed(n, old)
+	}
+}
+
+// Prune removes the entries whose magnitude is less than epsilon, in
+// place. Prune(math.SmallestNonzeroFloat64) removes just the stored
+// zeros. Use Compact afterwards to release the removed entries' memory.
+func (v *Vector) Prune(epsilon float64) {
+	for n, d := range v.data {
+		if math.Abs(d) < epsilon {
+			v.Remove(n)
+		}
+	}
+}
+
+// Clear removes all entries, keeping the Vector's dimensionality.
+func (v *Vector) Clear() {
+	clear(v.data)
+	v.invalidate()
+	if obs := v.opts.observerOf(); obs != nil {
+		obs.Cleared()
+	}
+}
+
+// lazyInit allocates storage on first write, so zero-value Vectors are
+// usable.
+func (v *Vector) lazyInit() {
+	if v.data == nil {
+		v.data = v.opts.newMap(0)
+	}
 }
 
 // Get data from the n'th dimension.
@@ -36,8 +136,39 @@ func (v Vector) Get(n int) float64 {
 	return v.data[n]
 }
 
-// Load data from an array of floats.
-func (v Vector) Load(data []float64) {
+// GetOK gets data from the n'th dimension, and reports whether it is
+// stored. This distinguishes an explicitly stored zero from an
+// implicit one.
+func (v Vector) GetOK(n int) (float64, bool) {
+	d, ok := v.data[n]
+	return d, ok
+}
+
+// GetChecked gets data from the n'th dimension, returning ErrOutOfRange
+// if n falls outside the Vector's dimensions.
+func (v Vector) GetChecked(n int) (float64, error) {
+	if n < 0 || n >= v.dim {
+		return 0, fmt.Errorf("%w: %d not in [0, %d)", ErrOutOfRange, n, v.dim)
+	}
+
+	return v.data[n], nil
+}
+
+// Has reports whether the Vector stores an entry on the n'th dimension,
+// as GetOK does.
+func (v Vector) Has(n int) bool {
+	_, ok := v.data[n]
+	return ok
+}
+
+// Load data from an array of floats, over any existing entries. Like
+// Set, it handles indices beyond the Vector's dimensions according to
+// its Bounds: by default it stores them without changing the Vector's
+// dimensionality, and it panics under Strict.
+//
+// Deprecated: Use FromDense, which constructs a Vector sized to the
+// slice.
+func (v *Vector) Load(data []float64) {
 	for i, f := range data {
 		if f != 0 {
 			v.Set(i, f)
@@ -45,6 +176,95 @@ func (v Vector) Load(data []float64) {
 	}
 }
 
+// IsZero reports whether every value of the Vector is zero, whether
+// implicitly or through stored zeros.
+func (v Vector) IsZero() bool {
+	for _, d := range v.data {
+		if d != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsEmpty reports whether the Vector stores no entries at all.
+func (v Vector) IsEmpty() bool {
+	return len(v.data) == 0
+}
+
+// NNZ is the number of non-zero entries stored in the Vector.
+func (v Vector) NNZ() int {
+	ret := 0
+	for _, d := range v.data {
+		if d != 0 {
+			ret++
+		}
+	}
+
+	return ret
+}
+
+// Density is the fraction of the Vector's dimensions that are non-zero,
+// or zero for a zero-dimensional Vector.
+func (v Vector) Density() float64 {
+	if v.dim == 0 {
+		return 0
+	}
+
+	return float64(v.NNZ()) / float64(v.dim)
+}
+
+// MemoryBytes estimates the memory held by the Vector and its storage,
+// in bytes, assuming entries are packed into the Go runtime's
+// hash-table groups of eight 16-byte slots at its maximum load factor
+// of 7/8. It counts the norm cache of WithCachedNorms but not the
+// options, which copies share. It may underestimate Vectors that once
+// stored more entries than they do now; see Compact. Compare it with
+// 8*Size() for the dense equivalent.
+func (v Vector) MemoryBytes() int {
+	const (
+		mapHeader = 48       // the runtime's map header
+		group     = 8 + 8*16 // control word and eight key/value slots
+	)
+
+	ret := int(unsafe.Sizeof(v))
+	if v.norms != nil {
+		ret += int(unsafe.Sizeof(*v.norms))
+	}
+
+	if v.data == nil {
+		return ret
+	}
+
+	groups := (len(v.data) + 6) / 7
+	return ret + mapHeader + groups*group
+}
+
+// ToDense materializes the Vector as a slice of its dim values. It is
+// the inverse of FromDense. Entries stored outside the Vector's dimensions
+// are left out.
+func (v Vector) ToDense() []float64 {
+	ret := make([]float64, v.dim)
+	for n, d := range v.data {
+		if n >= 0 && n < v.dim {
+			ret[n] = d
+		}
+	}
+
+	return ret
+}
+
+// ToMap returns a copy of the Vector's stored entries, keyed by index.
+func (v Vector) ToMap() map[int]float64 {
+	ret := make(map[int]float64, len(v.data))
+	for n, d := range v.data {
+		ret[n] = d
+	}
+
+	return ret
+}
+
 // Magnitude (scalar) of the vector.
 func (v Vector) Magnitude() float64 {
 	ret := float64(0)
@@ -57,7 +277,7 @@ func (v Vector) Magnitude() float64 {
 
 // Times a scalar, means multiple this vector with a scalar.
 func (v Vector) Times(scalar float64) Vector {
-	ret := v.clone()
+	ret := v.Clone()
 	for n, d := range ret.data {
 		ret.data[n] = d * scalar
 	}
@@ -65,22 +285,39 @@ func (v Vector) Times(scalar float64) Vector {
 	return ret
 }
 
-// Reduce Vector to just non-zero dimensions.
-func (v Vector) reduce() Vector {
-	ret := v.clone()
-	for n, d := range ret.data {
-		if d == 0 {
-			delete(ret.data, n)
+// Compact reduces a Vector to just its non-zero dimensions, returning a
+// new Vector without stored zeros. The new Vector's storage is sized to
+// its entries, releasing memory held by a heavily mutated one.
+func (v Vector) Compact() Vector {
+	ret := v
+	ret.data = make(map[int]float64, len(v.data))
+	ret.norms = v.opts.newNormCache()
+	for n, d := range v.data {
+		if d != 0 {
+			ret.data[n] = d
 		}
 	}
 
 	return ret
 }
 
-// Equals checks if this Vector is equal to another.
+// Shrink compacts a Vector like Compact, also shrinking its
+// dimensionality to one more than its highest non-zero index.
+func (v Vector) Shrink() Vector {
+	ret := v.Compact()
+	ret.dim = 0
+	for n := range ret.data {
+		ret.dim = max(ret.dim, n+1)
+	}
+
+	return ret
+}
+
+// Equals checks if this Vector has the same non-zero entries as
+// another, regardless of their dimensionality; see Equal.
 func (v Vector) Equals(other Vector) bool {
-	v = v.reduce()
-	other = other.reduce()
+	v = v.Compact()
+	other = other.Compact()
 	if len(v.data) == len(other.data) {
 		for n, d1 := range v.data {
 			if d2, ok := other.data[n]; !ok || d1 != d2 {
@@ -94,25 +331,116 @@ func (v Vector) Equals(other Vector) bool {
 	return false
 }
 
+// Equal reports whether two Vectors have the same dimensionality and
+// values. Stored and implicit zeros compare equal.
+func Equal(v1 Vector, v2 Vector) bool {
+	return ApproxEqual(v1, v2, 0)
+}
+
+// ApproxEqual reports whether two Vectors have the same dimensionality
+// and values that differ by at most tol. Stored and implicit zeros
+// compare equal, and NaNs never do.
+func ApproxEqual(v1 Vector, v2 Vector, tol float64) bool {
+	if v1.dim != v2.dim {
+		return false
+	}
+
+	ret := true
+	union(v1, v2, func(n int, d1 float64, d2 float64) {
+		ret = ret && (d1 == d2 || math.Abs(d1-d2) <= tol)
+	})
+
+	return ret
+}
+
+// String formats the Vector deterministically, listing its stored
+// entries in ascending index order, e.g. "dim=10 {1:0.5, 7:-2}".
 func (v Vector) String() string {
-	return fmt.Sprintf("%v", v.data)
+	var sb strings.Builder
+	sb.WriteString("dim=")
+	sb.WriteString(strconv.Itoa(v.dim))
+	sb.WriteString(" {")
+	for i, n := range slices.Sorted(maps.Keys(v.data)) {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(strconv.Itoa(n))
+		sb.WriteByte(':')
+		sb.WriteString(strconv.FormatFloat(v.data[n], 'g', -1, 64))
+	}
+
+	sb.WriteByte('}')
+	return sb.String()
 }
 
-// Append one Vector to another.
-func Append(v1 Vector, v2 Vector) Vector {
-	baseDim := v1.Size()
-	v1.dim = baseDim + v2.Size()
-	for n, d := range v2.data {
-		v1.Set(baseDim+n, d)
+// Append Vectors to v1, offsetting each one's indices by the total
+// dimensionality of those before it, into a new Vector with v1's
+// options. It is equivalent to Concat(v1, vs...). Append does not
+// validate its operands, unless v1's Bounds are Strict, when it panics
+// as AppendE errs; under AutoGrow, an operand's entries beyond its
+// dimensions grow the result to fit before the next operand.
+func Append(v1 Vector, vs ...Vector) Vector {
+	ret, err := appendVectors(v1, vs, false)
+	if err != nil {
+		panic(err)
 	}
 
-	return v1
+	return ret
+}
+
+// Concat joins Vectors end to end into a new Vector, offsetting each
+// one's indices by the total dimensionality of those before it. None of
+// vs is modified, and the result shares no storage with them. Concat
+// does not validate its operands; see ConcatE.
+func Concat(vs ...Vector) Vector {
+	return Append(NewVector(0), vs...)
 }
 
-// clone a Vector to a new instance to avoid side-effects.
-func (v Vector) clone() Vector {
+// appendVectors implements Append and AppendE. check, or Strict Bounds
+// on v1, rejects operands storing entries outside their dimensions.
+// Overflowing the result's dimensionality is always an error.
+func appendVectors(v1 Vector, vs []Vector, check bool) (Vector, error) {
+	check = check || v1.opts.boundsMode() == Strict
+	if check {
+		if err := checkInRange(v1); err != nil {
+			return Vector{}, err
+		}
+	}
+
+	ret := v1.Clone()
+	for _, v := range vs {
+		if check {
+			if err := checkInRange(v); err != nil {
+				return Vector{}, err
+			}
+		}
+
+		base := ret.dim
+		if v.dim > math.MaxInt-base {
+			return Vector{}, fmt.Errorf("%w: appended dimensionality overflows", ErrOutOfRange)
+		}
+
+		ret.dim += v.dim
+		for n, d := range v.data {
+			ret.data[base+n] = d
+			if n >= v.dim && ret.opts.boundsMode() == AutoGrow {
+				ret.dim = max(ret.dim, base+n+1)
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// Clone a Vector to a new instance with its own entries, to avoid
+// side-effects. The Clone keeps the Vector's options except its
+// Observer (see WithObserver).
+func (v Vector) Clone() Vector {
 	clone := v
-	clone.data = make(map[int]float64)
+	clone.opts = v.opts.withoutObserver()
+	clone.data = v.opts.newMap(len(v.data))
+	clone.norms = v.opts.newNormCache()
 	for n, d := range v.data {
 		clone.data[n] = d
 	}
@@ -120,71 +448,156 @@ func (v Vector) clone() Vector {
 	return clone
 }
 
-// smallerBigger is a private helper that helps sort two vector based
-// on which one is less sparse (smaller) than the other (bigger)
-func smallerBigger(v1 Vector, v2 Vector) (*Vector, *Vector) {
-	var (
-		smaller *Vector
-		bigger  *Vector
-	)
-
-	smaller = &v1
-	bigger = &v1
-	if len(v2.data) >= len(v1.data) {
-		bigger = &v2
-	} else {
-		smaller = &v2
+// Add two Vectors. Add does not check that their dimensions match: the
+// result takes the dimensionality of whichever operand has more stored
+// entries, or v2's on a tie. See AddE for a checked variant.
+func Add(v1 Vector, v2 Vector) Vector {
+	if len(v2.data) < len(v1.data) {
+		v1, v2 = v2, v1
 	}
 
-	return smaller, bigger
-}
-
-// Add two Vectors.
-func Add(v1 Vector, v2 Vector) Vector {
-	smaller, bigger := smallerBigger(v1, v2)
-	biggerClone := (*bigger).clone()
-	for n, d := range (*smaller).data {
-		biggerClone.data[n] += d
+	ret := v2.Clone()
+	for n, d := range v1.data {
+		ret.data[n] += d
 	}
 
-	return biggerClone
+	return ret
 }
 
-// Dot product of two Vectors.
+// Dot product of two Vectors: the sum of the products of the values at
+// each index stored in both. It costs O(min(nnz(v1), nnz(v2))), probing
+// the Vector with more entries for each index of the other. Dot does
+// not check that their dimensions match; see DotE for a checked
+// variant.
+//
+// Products are summed in map iteration order, so the last bits of the
+// result can differ between calls; CompressedVector.Dot sums in index
+// order and is reproducible.
 func Dot(v1 Vector, v2 Vector) float64 {
 	ret := float64(0)
-	smaller, bigger := smallerBigger(v1, v2)
-
-	for n := range (*smaller).data {
-		ret += (*smaller).Get(n) * (*bigger).Get(n)
-	}
+	intersect(v1, v2, func(n int, d1 float64, d2 float64) {
+		ret += d1 * d2
+	})
 
 	return ret
 }
 
-// Acos is a measure of similarity between vectors.
+// intersect calls fn for every index stored in both v1 and v2, by
+// probing the larger Vector with each index of the smaller.
+func intersect(v1 Vector, v2 Vector, fn func(n int, d1 float64, d2 float64)) {
+	if len(v1.data) <= len(v2.data) {
+		for n, d1 := range v1.data {
+			if d2, ok := v2.data[n]; ok {
+				fn(n, d1, d2)
+			}
+		}
+	} else {
+		for n, d2 := range v2.data {
+			if d1, ok := v1.data[n]; ok {
+				fn(n, d1, d2)
+			}
+		}
+	}
+}
+
+// Acos is the angle between two Vectors, in radians: a measure of
+// their similarity. It is computed without taking the arc cosine of
+// their Similarity, which loses precision for nearly parallel Vectors.
+// It is NaN if either is a zero vector; see AcosE.
 func Acos(v1 Vector, v2 Vector) float64 {
-	dotProduct := Dot(v1, v2)
-	scalarProduct := v1.Magnitude() * v2.Magnitude()
-	return math.Acos(dotProduct / scalarProduct)
+	return angle(v1, v2)
 }
 
-// Similarity is a convenience function for Cos(Acos(v1, v2)).
+// Similarity is the cosine similarity of two Vectors, Cos(Acos(v1, v2)),
+// clamped to [-1, 1] against rounding. It is NaN if either is a zero
+// vector, whose direction is undefined; see SimilarityE.
 func Similarity(v1 Vector, v2 Vector) float64 {
-	return math.Cos(Acos(v1, v2))
+	return cosine(v1, v2, v1.CachedMagnitude(), v2.CachedMagnitude())
+}
+
+// cosine is the cosine of the angle between two Vectors with the given
+// magnitudes, clamped to [-1, 1] against rounding. It is NaN if either
+// is a zero vector.
+func cosine(v1 Vector, v2 Vector, norm1 float64, norm2 float64) float64 {
+	if norm1 == 0 || norm2 == 0 {
+		return math.NaN()
+	}
+
+	return max(-1, min(1, Dot(v1, v2)/(norm1*norm2)))
 }
 
 // NewVector constructs a blank Vector with dim number of dimensions.
-func NewVector(dim int) Vector {
+func NewVector(dim int, opts ...Option) Vector {
+	o := newOptions(opts)
 	return Vector{
-		dim:  dim,
-		data: map[int]float64{},
+		dim:   dim,
+		data:  o.newMap(o.capacityHint()),
+		opts:  o,
+		norms: o.newNormCache(),
 	}
 }
 
-// NewVectorFromArray maps an array to a Vector.
-func NewVectorFromArray(arr []float64) Vector {
-	ret := NewVector(len(arr))
-	ret.Load(arr)
+// NewVectorFromArray maps an array to a Vector. It is equivalent to
+// FromDense.
+func NewVectorFromArray(arr []float64, opts ...Option) Vector {
+	return FromDense(arr, opts...)
+}
+
+// FromDense constructs a Vector with one dimension per element of arr,
+// storing its non-zero values. It is the inverse of ToDense.
+func FromDense(arr []float64, opts ...Option) Vector {
+	ret := NewVector(len(arr), opts...)
+	for n, d := range arr {
+		if d != 0 {
+			ret.Set(n, d)
+		}
+	}
+
 	return ret
 }
+
+// NewVectorFromMap copies entries into a Vector with dim number of
+// dimensions, returning ErrOutOfRange if an index falls outside them.
+func NewVectorFromMap(dim int, entries map[int]float64, opts ...Option) (Vector, error) {
+	ret := NewVector(dim, append([]Option{WithCapacity(len(entries))}, opts...)...)
+	for n, d := range entries {
+		if err := ret.SetChecked(n, d); err != nil {
+			return Vector{}, err
+		}
+	}
+
+	return ret, nil
+}
+
+// NewVectorFromPairs builds a Vector with dim number of dimensions from
+// parallel slices of indices and values, as found in CSR-style sources.
+// It returns ErrOutOfRange if an index falls outside the dimensions,
+// and handles repeated indices according to the DuplicatePolicy given
+// by WithDuplicates.
+func NewVectorFromPairs(dim int, indices []int, values []float64, opts ...Option) (Vector, error) {
+	if len(indices) != len(values) {
+		return Vector{}, fmt.Errorf("%w: %d indices but %d values", ErrDimensionMismatch, len(indices), len(values))
+	}
+
+	// seen tracks the indices given rather than those stored, which
+	// leave out values the options drop.
+	ret := NewVector(dim, append([]Option{WithCapacity(len(indices))}, opts...)...)
+	var seen map[int]struct{}
+	if ret.opts.duplicatePolicy() == DuplicateError {
+		seen = make(map[int]struct{}, len(indices))
+	}
+
+	for i, n := range indices {
+		if _, dup := seen[n]; dup {
+			return Vector{}, fmt.Errorf("%w: %d", ErrDuplicateIndex, n)
+		} else if seen != nil {
+			seen[n] = struct{}{}
+		}
+
+		if err := ret.SetChecked(n, ret.data[n]+values[i]); err != nil {
+			return Vector{}, err
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,30 @@
+package sparse
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewVectorFromPairsDuplicates(t *testing.T) {
+	for name, tt := range map[string]struct {
+		values []float64
+		opts   []Option
+	}{
+		"plain":     {[]float64{1, 2}, nil},
+		"zero":      {[]float64{0, 2}, nil},
+		"tolerance": {[]float64{0.001, 2}, []Option{WithTolerance(0.01)}},
+		"NaNDrop":   {[]float64{math.NaN(), 2}, []Option{WithNaNPolicy(NaNDrop)}},
+	} {
+		if _, err := NewVectorFromPairs(3, []int{1, 1}, tt.values, tt.opts...); !errors.Is(err, ErrDuplicateIndex) {
+			t.Errorf("%s: got %v, want ErrDuplicateIndex", name, err)
+		}
+	}
+
+	v, err := NewVectorFromPairs(3, []int{1, 2, 1}, []float64{1, 5, 2}, WithDuplicates(DuplicateSum))
+	if err != nil {
+		t.Fatal(err)
+	} else if v.Get(1) != 3 || v.Get(2) != 5 {
+		t.Errorf("DuplicateSum gave %v, want 3 at 1 and 5 at 2", v)
+	}
+}
@@ -0,0 +1,97 @@
+package sparse
+
+// WindowAggregator maintains the sum of the last N Vectors pushed to it,
+// evicting the oldest contribution as each new one arrives.
+type WindowAggregator struct {
+	window []Vector
+	next   int
+	full   bool
+	sum    map[int]float64
+	counts map[int]int // window Vectors storing each index of sum
+}
+
+// NewWindowAggregator constructs a WindowAggregator over the last n
+// Vectors. It panics if n is not positive.
+func NewWindowAggregator(n int) *WindowAggregator {
+	if n <= 0 {
+		panic("sparse: window size must be positive")
+	}
+
+	return &WindowAggregator{
+		window: make([]Vector, n),
+		sum:    map[int]float64{},
+		counts: map[int]int{},
+	}
+}
+
+// Push v into the window, evicting the oldest Vector once it is full. v
+// is copied, reusing the storage of the evicted Vector.
+func (w *WindowAggregator) Push(v Vector) {
+	slot := &w.window[w.next]
+	if w.full {
+		for n, d := range slot.data {
+			if w.counts[n]--; w.counts[n] == 0 {
+				delete(w.counts, n)
+				delete(w.sum, n)
+			} else {
+				w.sum[n] -= d
+			}
+		}
+	}
+
+	if slot.data == nil {
+		slot.data = make(map[int]float64, len(v.data))
+	} else {
+		clear(slot.data)
+	}
+
+	slot.dim = v.dim
+	for n, d := range v.data {
+		slot.data[n] = d
+		w.sum[n] += d
+		w.counts[n]++
+	}
+
+	w.next = (w.next + 1) % len(w.window)
+	w.full = w.full || w.next == 0
+}
+
+// Len is the number of Vectors currently in the window.
+func (w *WindowAggregator) Len() int {
+	if w.full {
+		return len(w.window)
+	}
+
+	return w.next
+}
+
+// dim is the largest dimensionality in the window.
+func (w *WindowAggregator) dim() int {
+	ret := 0
+	for _, v := range w.window[:w.Len()] {
+		ret = max(ret, v.dim)
+	}
+
+	return ret
+}
+
+// Sum of the Vectors in the window, with the largest dimensionality
+// among them.
+func (w *WindowAggregator) Sum() Vector {
+	ret := NewVector(w.dim(), WithCapacity(len(w.sum)))
+	for n, d := range w.sum {
+		ret.data[n] = d
+	}
+
+	return ret
+}
+
+// Mean of the Vectors in the window, or a zero-dimensional Vector if it
+// is empty.
+func (w *WindowAggregator) Mean() Vector {
+	if w.Len() == 0 {
+		return Vector{}
+	}
+
+	return w.Sum().Times(1 / float64(w.Len()))
+}